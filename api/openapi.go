@@ -0,0 +1,8 @@
+package telegram
+
+import _ "embed"
+
+// OpenAPISpec describes the http/json api of the service, it's generated by make generate
+//
+//go:embed telegram_notification.swagger.json
+var OpenAPISpec []byte
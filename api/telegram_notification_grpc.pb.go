@@ -25,14 +25,60 @@ type TelegramNotificationServiceClient interface {
 	SendNotification(ctx context.Context, in *SendNotificationRequest, opts ...grpc.CallOption) (*SendNotificationResponse, error)
 	GetNotification(ctx context.Context, in *GetNotificationRequest, opts ...grpc.CallOption) (*GetNotificationResponse, error)
 	GetNotifications(ctx context.Context, in *GetNotificationsRequest, opts ...grpc.CallOption) (*GetNotificationsResponse, error)
+	SearchNotifications(ctx context.Context, in *SearchNotificationsRequest, opts ...grpc.CallOption) (*SearchNotificationsResponse, error)
+	WatchNotification(ctx context.Context, in *WatchNotificationRequest, opts ...grpc.CallOption) (TelegramNotificationService_WatchNotificationClient, error)
+	GetUserInbox(ctx context.Context, in *GetUserInboxRequest, opts ...grpc.CallOption) (*GetUserInboxResponse, error)
+	GetNotificationStats(ctx context.Context, in *GetNotificationStatsRequest, opts ...grpc.CallOption) (*GetNotificationStatsResponse, error)
+	GetNotificationEngagement(ctx context.Context, in *GetNotificationEngagementRequest, opts ...grpc.CallOption) (*GetNotificationEngagementResponse, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	GetUserByTelegramID(ctx context.Context, in *GetUserByTelegramIDRequest, opts ...grpc.CallOption) (*GetUserByTelegramIDResponse, error)
 	GetUsersById(ctx context.Context, in *GetUsersByIdRequest, opts ...grpc.CallOption) (*GetUsersByIdResponse, error)
 	GetUsersByFilter(ctx context.Context, in *GetUsersByFilterRequest, opts ...grpc.CallOption) (*GetUsersByFilterResponse, error)
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (TelegramNotificationService_ExportUsersClient, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	EditUser(ctx context.Context, in *EditUserRequest, opts ...grpc.CallOption) (*EditUserResponse, error)
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (TelegramNotificationService_ImportUsersClient, error)
+	BatchCreateUsers(ctx context.Context, in *BatchCreateUsersRequest, opts ...grpc.CallOption) (*BatchCreateUsersResponse, error)
+	BatchEditUsers(ctx context.Context, in *BatchEditUsersRequest, opts ...grpc.CallOption) (*BatchEditUsersResponse, error)
+	BatchDeleteUsers(ctx context.Context, in *BatchDeleteUsersRequest, opts ...grpc.CallOption) (*BatchDeleteUsersResponse, error)
+	GetUserDuplicates(ctx context.Context, in *GetUserDuplicatesRequest, opts ...grpc.CallOption) (*GetUserDuplicatesResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
 	GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error)
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	EditGroup(ctx context.Context, in *EditGroupRequest, opts ...grpc.CallOption) (*EditGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	RenameGroup(ctx context.Context, in *RenameGroupRequest, opts ...grpc.CallOption) (*RenameGroupResponse, error)
+	MergeGroups(ctx context.Context, in *MergeGroupsRequest, opts ...grpc.CallOption) (*MergeGroupsResponse, error)
+	AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error)
+	RemoveUserFromGroup(ctx context.Context, in *RemoveUserFromGroupRequest, opts ...grpc.CallOption) (*RemoveUserFromGroupResponse, error)
+	GetGroupTree(ctx context.Context, in *GetGroupTreeRequest, opts ...grpc.CallOption) (*GetGroupTreeResponse, error)
+	AddGroupModerator(ctx context.Context, in *AddGroupModeratorRequest, opts ...grpc.CallOption) (*AddGroupModeratorResponse, error)
+	RemoveGroupModerator(ctx context.Context, in *RemoveGroupModeratorRequest, opts ...grpc.CallOption) (*RemoveGroupModeratorResponse, error)
+	CreateApiClient(ctx context.Context, in *CreateApiClientRequest, opts ...grpc.CallOption) (*CreateApiClientResponse, error)
+	GetApiClient(ctx context.Context, in *GetApiClientRequest, opts ...grpc.CallOption) (*GetApiClientResponse, error)
+	GetApiClients(ctx context.Context, in *GetApiClientsRequest, opts ...grpc.CallOption) (*GetApiClientsResponse, error)
+	EditApiClient(ctx context.Context, in *EditApiClientRequest, opts ...grpc.CallOption) (*EditApiClientResponse, error)
+	DeleteApiClient(ctx context.Context, in *DeleteApiClientRequest, opts ...grpc.CallOption) (*DeleteApiClientResponse, error)
+	GetClientUsage(ctx context.Context, in *GetClientUsageRequest, opts ...grpc.CallOption) (*GetClientUsageResponse, error)
+	CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error)
+	GetTopic(ctx context.Context, in *GetTopicRequest, opts ...grpc.CallOption) (*GetTopicResponse, error)
+	GetTopics(ctx context.Context, in *GetTopicsRequest, opts ...grpc.CallOption) (*GetTopicsResponse, error)
+	EditTopic(ctx context.Context, in *EditTopicRequest, opts ...grpc.CallOption) (*EditTopicResponse, error)
+	DeleteTopic(ctx context.Context, in *DeleteTopicRequest, opts ...grpc.CallOption) (*DeleteTopicResponse, error)
+	SubscribeToTopic(ctx context.Context, in *SubscribeToTopicRequest, opts ...grpc.CallOption) (*SubscribeToTopicResponse, error)
+	UnsubscribeFromTopic(ctx context.Context, in *UnsubscribeFromTopicRequest, opts ...grpc.CallOption) (*UnsubscribeFromTopicResponse, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	GetWebhooks(ctx context.Context, in *GetWebhooksRequest, opts ...grpc.CallOption) (*GetWebhooksResponse, error)
+	EditWebhook(ctx context.Context, in *EditWebhookRequest, opts ...grpc.CallOption) (*EditWebhookResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error)
+	RetryWebhookDelivery(ctx context.Context, in *RetryWebhookDeliveryRequest, opts ...grpc.CallOption) (*RetryWebhookDeliveryResponse, error)
 }
 
 type telegramNotificationServiceClient struct {
@@ -70,6 +116,74 @@ func (c *telegramNotificationServiceClient) GetNotifications(ctx context.Context
 	return out, nil
 }
 
+func (c *telegramNotificationServiceClient) SearchNotifications(ctx context.Context, in *SearchNotificationsRequest, opts ...grpc.CallOption) (*SearchNotificationsResponse, error) {
+	out := new(SearchNotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/SearchNotifications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) WatchNotification(ctx context.Context, in *WatchNotificationRequest, opts ...grpc.CallOption) (TelegramNotificationService_WatchNotificationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TelegramNotificationService_ServiceDesc.Streams[0], "/notification.v1.telegram_notification_service/WatchNotification", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &telegramNotificationServiceWatchNotificationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TelegramNotificationService_WatchNotificationClient interface {
+	Recv() (*WatchNotificationResponse, error)
+	grpc.ClientStream
+}
+
+type telegramNotificationServiceWatchNotificationClient struct {
+	grpc.ClientStream
+}
+
+func (x *telegramNotificationServiceWatchNotificationClient) Recv() (*WatchNotificationResponse, error) {
+	m := new(WatchNotificationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *telegramNotificationServiceClient) GetUserInbox(ctx context.Context, in *GetUserInboxRequest, opts ...grpc.CallOption) (*GetUserInboxResponse, error) {
+	out := new(GetUserInboxResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetUserInbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetNotificationStats(ctx context.Context, in *GetNotificationStatsRequest, opts ...grpc.CallOption) (*GetNotificationStatsResponse, error) {
+	out := new(GetNotificationStatsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetNotificationStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetNotificationEngagement(ctx context.Context, in *GetNotificationEngagementRequest, opts ...grpc.CallOption) (*GetNotificationEngagementResponse, error) {
+	out := new(GetNotificationEngagementResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetNotificationEngagement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *telegramNotificationServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
 	out := new(GetUserResponse)
 	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetUser", in, out, opts...)
@@ -106,6 +220,38 @@ func (c *telegramNotificationServiceClient) GetUsersByFilter(ctx context.Context
 	return out, nil
 }
 
+func (c *telegramNotificationServiceClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (TelegramNotificationService_ExportUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TelegramNotificationService_ServiceDesc.Streams[1], "/notification.v1.telegram_notification_service/ExportUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &telegramNotificationServiceExportUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TelegramNotificationService_ExportUsersClient interface {
+	Recv() (*ExportUsersResponse, error)
+	grpc.ClientStream
+}
+
+type telegramNotificationServiceExportUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *telegramNotificationServiceExportUsersClient) Recv() (*ExportUsersResponse, error) {
+	m := new(ExportUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *telegramNotificationServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
 	out := new(DeleteUserResponse)
 	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/DeleteUser", in, out, opts...)
@@ -121,290 +267,1701 @@ func (c *telegramNotificationServiceClient) EditUser(ctx context.Context, in *Ed
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (TelegramNotificationService_ImportUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TelegramNotificationService_ServiceDesc.Streams[2], "/notification.v1.telegram_notification_service/ImportUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &telegramNotificationServiceImportUsersClient{stream}
+	return x, nil
+}
+
+type TelegramNotificationService_ImportUsersClient interface {
+	Send(*ImportUsersRequest) error
+	CloseAndRecv() (*ImportUsersResponse, error)
+	grpc.ClientStream
+}
+
+type telegramNotificationServiceImportUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *telegramNotificationServiceImportUsersClient) Send(m *ImportUsersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *telegramNotificationServiceImportUsersClient) CloseAndRecv() (*ImportUsersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *telegramNotificationServiceClient) BatchCreateUsers(ctx context.Context, in *BatchCreateUsersRequest, opts ...grpc.CallOption) (*BatchCreateUsersResponse, error) {
+	out := new(BatchCreateUsersResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/BatchCreateUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) BatchEditUsers(ctx context.Context, in *BatchEditUsersRequest, opts ...grpc.CallOption) (*BatchEditUsersResponse, error) {
+	out := new(BatchEditUsersResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/BatchEditUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) BatchDeleteUsers(ctx context.Context, in *BatchDeleteUsersRequest, opts ...grpc.CallOption) (*BatchDeleteUsersResponse, error) {
+	out := new(BatchDeleteUsersResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/BatchDeleteUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetUserDuplicates(ctx context.Context, in *GetUserDuplicatesRequest, opts ...grpc.CallOption) (*GetUserDuplicatesResponse, error) {
+	out := new(GetUserDuplicatesResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetUserDuplicates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error) {
+	out := new(PurgeUserResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/PurgeUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/EraseUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
+	out := new(GetGroupsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	out := new(GetGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) EditGroup(ctx context.Context, in *EditGroupRequest, opts ...grpc.CallOption) (*EditGroupResponse, error) {
+	out := new(EditGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/EditGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) RenameGroup(ctx context.Context, in *RenameGroupRequest, opts ...grpc.CallOption) (*RenameGroupResponse, error) {
+	out := new(RenameGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/RenameGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) MergeGroups(ctx context.Context, in *MergeGroupsRequest, opts ...grpc.CallOption) (*MergeGroupsResponse, error) {
+	out := new(MergeGroupsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/MergeGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error) {
+	out := new(AddUserToGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/AddUserToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) RemoveUserFromGroup(ctx context.Context, in *RemoveUserFromGroupRequest, opts ...grpc.CallOption) (*RemoveUserFromGroupResponse, error) {
+	out := new(RemoveUserFromGroupResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/RemoveUserFromGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetGroupTree(ctx context.Context, in *GetGroupTreeRequest, opts ...grpc.CallOption) (*GetGroupTreeResponse, error) {
+	out := new(GetGroupTreeResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetGroupTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) AddGroupModerator(ctx context.Context, in *AddGroupModeratorRequest, opts ...grpc.CallOption) (*AddGroupModeratorResponse, error) {
+	out := new(AddGroupModeratorResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/AddGroupModerator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) RemoveGroupModerator(ctx context.Context, in *RemoveGroupModeratorRequest, opts ...grpc.CallOption) (*RemoveGroupModeratorResponse, error) {
+	out := new(RemoveGroupModeratorResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/RemoveGroupModerator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) CreateApiClient(ctx context.Context, in *CreateApiClientRequest, opts ...grpc.CallOption) (*CreateApiClientResponse, error) {
+	out := new(CreateApiClientResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateApiClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetApiClient(ctx context.Context, in *GetApiClientRequest, opts ...grpc.CallOption) (*GetApiClientResponse, error) {
+	out := new(GetApiClientResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetApiClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetApiClients(ctx context.Context, in *GetApiClientsRequest, opts ...grpc.CallOption) (*GetApiClientsResponse, error) {
+	out := new(GetApiClientsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetApiClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) EditApiClient(ctx context.Context, in *EditApiClientRequest, opts ...grpc.CallOption) (*EditApiClientResponse, error) {
+	out := new(EditApiClientResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/EditApiClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) DeleteApiClient(ctx context.Context, in *DeleteApiClientRequest, opts ...grpc.CallOption) (*DeleteApiClientResponse, error) {
+	out := new(DeleteApiClientResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/DeleteApiClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetClientUsage(ctx context.Context, in *GetClientUsageRequest, opts ...grpc.CallOption) (*GetClientUsageResponse, error) {
+	out := new(GetClientUsageResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetClientUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error) {
+	out := new(CreateTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetTopic(ctx context.Context, in *GetTopicRequest, opts ...grpc.CallOption) (*GetTopicResponse, error) {
+	out := new(GetTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetTopics(ctx context.Context, in *GetTopicsRequest, opts ...grpc.CallOption) (*GetTopicsResponse, error) {
+	out := new(GetTopicsResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetTopics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) EditTopic(ctx context.Context, in *EditTopicRequest, opts ...grpc.CallOption) (*EditTopicResponse, error) {
+	out := new(EditTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/EditTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) DeleteTopic(ctx context.Context, in *DeleteTopicRequest, opts ...grpc.CallOption) (*DeleteTopicResponse, error) {
+	out := new(DeleteTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/DeleteTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) SubscribeToTopic(ctx context.Context, in *SubscribeToTopicRequest, opts ...grpc.CallOption) (*SubscribeToTopicResponse, error) {
+	out := new(SubscribeToTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/SubscribeToTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) UnsubscribeFromTopic(ctx context.Context, in *UnsubscribeFromTopicRequest, opts ...grpc.CallOption) (*UnsubscribeFromTopicResponse, error) {
+	out := new(UnsubscribeFromTopicResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/UnsubscribeFromTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error) {
+	out := new(GetAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetWebhooks(ctx context.Context, in *GetWebhooksRequest, opts ...grpc.CallOption) (*GetWebhooksResponse, error) {
+	out := new(GetWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) EditWebhook(ctx context.Context, in *EditWebhookRequest, opts ...grpc.CallOption) (*EditWebhookResponse, error) {
+	out := new(EditWebhookResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/EditWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error) {
+	out := new(GetWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramNotificationServiceClient) RetryWebhookDelivery(ctx context.Context, in *RetryWebhookDeliveryRequest, opts ...grpc.CallOption) (*RetryWebhookDeliveryResponse, error) {
+	out := new(RetryWebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/RetryWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TelegramNotificationServiceServer is the server API for TelegramNotificationService service.
+// All implementations must embed UnimplementedTelegramNotificationServiceServer
+// for forward compatibility
+type TelegramNotificationServiceServer interface {
+	SendNotification(context.Context, *SendNotificationRequest) (*SendNotificationResponse, error)
+	GetNotification(context.Context, *GetNotificationRequest) (*GetNotificationResponse, error)
+	GetNotifications(context.Context, *GetNotificationsRequest) (*GetNotificationsResponse, error)
+	SearchNotifications(context.Context, *SearchNotificationsRequest) (*SearchNotificationsResponse, error)
+	WatchNotification(*WatchNotificationRequest, TelegramNotificationService_WatchNotificationServer) error
+	GetUserInbox(context.Context, *GetUserInboxRequest) (*GetUserInboxResponse, error)
+	GetNotificationStats(context.Context, *GetNotificationStatsRequest) (*GetNotificationStatsResponse, error)
+	GetNotificationEngagement(context.Context, *GetNotificationEngagementRequest) (*GetNotificationEngagementResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	GetUserByTelegramID(context.Context, *GetUserByTelegramIDRequest) (*GetUserByTelegramIDResponse, error)
+	GetUsersById(context.Context, *GetUsersByIdRequest) (*GetUsersByIdResponse, error)
+	GetUsersByFilter(context.Context, *GetUsersByFilterRequest) (*GetUsersByFilterResponse, error)
+	ExportUsers(*ExportUsersRequest, TelegramNotificationService_ExportUsersServer) error
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	EditUser(context.Context, *EditUserRequest) (*EditUserResponse, error)
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	ImportUsers(TelegramNotificationService_ImportUsersServer) error
+	BatchCreateUsers(context.Context, *BatchCreateUsersRequest) (*BatchCreateUsersResponse, error)
+	BatchEditUsers(context.Context, *BatchEditUsersRequest) (*BatchEditUsersResponse, error)
+	BatchDeleteUsers(context.Context, *BatchDeleteUsersRequest) (*BatchDeleteUsersResponse, error)
+	GetUserDuplicates(context.Context, *GetUserDuplicatesRequest) (*GetUserDuplicatesResponse, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error)
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error)
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	EditGroup(context.Context, *EditGroupRequest) (*EditGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	RenameGroup(context.Context, *RenameGroupRequest) (*RenameGroupResponse, error)
+	MergeGroups(context.Context, *MergeGroupsRequest) (*MergeGroupsResponse, error)
+	AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error)
+	RemoveUserFromGroup(context.Context, *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error)
+	GetGroupTree(context.Context, *GetGroupTreeRequest) (*GetGroupTreeResponse, error)
+	AddGroupModerator(context.Context, *AddGroupModeratorRequest) (*AddGroupModeratorResponse, error)
+	RemoveGroupModerator(context.Context, *RemoveGroupModeratorRequest) (*RemoveGroupModeratorResponse, error)
+	CreateApiClient(context.Context, *CreateApiClientRequest) (*CreateApiClientResponse, error)
+	GetApiClient(context.Context, *GetApiClientRequest) (*GetApiClientResponse, error)
+	GetApiClients(context.Context, *GetApiClientsRequest) (*GetApiClientsResponse, error)
+	EditApiClient(context.Context, *EditApiClientRequest) (*EditApiClientResponse, error)
+	DeleteApiClient(context.Context, *DeleteApiClientRequest) (*DeleteApiClientResponse, error)
+	GetClientUsage(context.Context, *GetClientUsageRequest) (*GetClientUsageResponse, error)
+	CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error)
+	GetTopic(context.Context, *GetTopicRequest) (*GetTopicResponse, error)
+	GetTopics(context.Context, *GetTopicsRequest) (*GetTopicsResponse, error)
+	EditTopic(context.Context, *EditTopicRequest) (*EditTopicResponse, error)
+	DeleteTopic(context.Context, *DeleteTopicRequest) (*DeleteTopicResponse, error)
+	SubscribeToTopic(context.Context, *SubscribeToTopicRequest) (*SubscribeToTopicResponse, error)
+	UnsubscribeFromTopic(context.Context, *UnsubscribeFromTopicRequest) (*UnsubscribeFromTopicResponse, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	GetWebhooks(context.Context, *GetWebhooksRequest) (*GetWebhooksResponse, error)
+	EditWebhook(context.Context, *EditWebhookRequest) (*EditWebhookResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error)
+	RetryWebhookDelivery(context.Context, *RetryWebhookDeliveryRequest) (*RetryWebhookDeliveryResponse, error)
+	mustEmbedUnimplementedTelegramNotificationServiceServer()
+}
+
+// UnimplementedTelegramNotificationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTelegramNotificationServiceServer struct {
+}
+
+func (UnimplementedTelegramNotificationServiceServer) SendNotification(context.Context, *SendNotificationRequest) (*SendNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendNotification not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetNotification(context.Context, *GetNotificationRequest) (*GetNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotification not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetNotifications(context.Context, *GetNotificationsRequest) (*GetNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotifications not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) SearchNotifications(context.Context, *SearchNotificationsRequest) (*SearchNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNotifications not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) WatchNotification(*WatchNotificationRequest, TelegramNotificationService_WatchNotificationServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotification not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUserInbox(context.Context, *GetUserInboxRequest) (*GetUserInboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserInbox not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetNotificationStats(context.Context, *GetNotificationStatsRequest) (*GetNotificationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationStats not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetNotificationEngagement(context.Context, *GetNotificationEngagementRequest) (*GetNotificationEngagementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationEngagement not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUserByTelegramID(context.Context, *GetUserByTelegramIDRequest) (*GetUserByTelegramIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByTelegramID not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUsersById(context.Context, *GetUsersByIdRequest) (*GetUsersByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersById not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUsersByFilter(context.Context, *GetUsersByFilterRequest) (*GetUsersByFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByFilter not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) ExportUsers(*ExportUsersRequest, TelegramNotificationService_ExportUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EditUser(context.Context, *EditUserRequest) (*EditUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) ImportUsers(TelegramNotificationService_ImportUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) BatchCreateUsers(context.Context, *BatchCreateUsersRequest) (*BatchCreateUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateUsers not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) BatchEditUsers(context.Context, *BatchEditUsersRequest) (*BatchEditUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchEditUsers not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) BatchDeleteUsers(context.Context, *BatchDeleteUsersRequest) (*BatchDeleteUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteUsers not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetUserDuplicates(context.Context, *GetUserDuplicatesRequest) (*GetUserDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserDuplicates not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUser not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroups not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EditGroup(context.Context, *EditGroupRequest) (*EditGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) RenameGroup(context.Context, *RenameGroupRequest) (*RenameGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) MergeGroups(context.Context, *MergeGroupsRequest) (*MergeGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeGroups not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) RemoveUserFromGroup(context.Context, *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserFromGroup not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetGroupTree(context.Context, *GetGroupTreeRequest) (*GetGroupTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupTree not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) AddGroupModerator(context.Context, *AddGroupModeratorRequest) (*AddGroupModeratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupModerator not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) RemoveGroupModerator(context.Context, *RemoveGroupModeratorRequest) (*RemoveGroupModeratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupModerator not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) CreateApiClient(context.Context, *CreateApiClientRequest) (*CreateApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiClient not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetApiClient(context.Context, *GetApiClientRequest) (*GetApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApiClient not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetApiClients(context.Context, *GetApiClientsRequest) (*GetApiClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApiClients not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EditApiClient(context.Context, *EditApiClientRequest) (*EditApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditApiClient not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) DeleteApiClient(context.Context, *DeleteApiClientRequest) (*DeleteApiClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiClient not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetClientUsage(context.Context, *GetClientUsageRequest) (*GetClientUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientUsage not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetTopic(context.Context, *GetTopicRequest) (*GetTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetTopics(context.Context, *GetTopicsRequest) (*GetTopicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopics not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EditTopic(context.Context, *EditTopicRequest) (*EditTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) DeleteTopic(context.Context, *DeleteTopicRequest) (*DeleteTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) SubscribeToTopic(context.Context, *SubscribeToTopicRequest) (*SubscribeToTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeToTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) UnsubscribeFromTopic(context.Context, *UnsubscribeFromTopicRequest) (*UnsubscribeFromTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeFromTopic not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetWebhooks(context.Context, *GetWebhooksRequest) (*GetWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhooks not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) EditWebhook(context.Context, *EditWebhookRequest) (*EditWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditWebhook not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) RetryWebhookDelivery(context.Context, *RetryWebhookDeliveryRequest) (*RetryWebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryWebhookDelivery not implemented")
+}
+func (UnimplementedTelegramNotificationServiceServer) mustEmbedUnimplementedTelegramNotificationServiceServer() {
+}
+
+// UnsafeTelegramNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TelegramNotificationServiceServer will
+// result in compilation errors.
+type UnsafeTelegramNotificationServiceServer interface {
+	mustEmbedUnimplementedTelegramNotificationServiceServer()
+}
+
+func RegisterTelegramNotificationServiceServer(s grpc.ServiceRegistrar, srv TelegramNotificationServiceServer) {
+	s.RegisterService(&TelegramNotificationService_ServiceDesc, srv)
+}
+
+func _TelegramNotificationService_SendNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).SendNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/SendNotification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).SendNotification(ctx, req.(*SendNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetNotification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetNotification(ctx, req.(*GetNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetNotifications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetNotifications(ctx, req.(*GetNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_SearchNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).SearchNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/SearchNotifications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).SearchNotifications(ctx, req.(*SearchNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_WatchNotification_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotificationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TelegramNotificationServiceServer).WatchNotification(m, &telegramNotificationServiceWatchNotificationServer{stream})
+}
+
+type TelegramNotificationService_WatchNotificationServer interface {
+	Send(*WatchNotificationResponse) error
+	grpc.ServerStream
+}
+
+type telegramNotificationServiceWatchNotificationServer struct {
+	grpc.ServerStream
+}
+
+func (x *telegramNotificationServiceWatchNotificationServer) Send(m *WatchNotificationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TelegramNotificationService_GetUserInbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserInboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUserInbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUserInbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUserInbox(ctx, req.(*GetUserInboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetNotificationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetNotificationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetNotificationStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetNotificationStats(ctx, req.(*GetNotificationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetNotificationEngagement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationEngagementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetNotificationEngagement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetNotificationEngagement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetNotificationEngagement(ctx, req.(*GetNotificationEngagementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetUserByTelegramID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByTelegramIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUserByTelegramID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUserByTelegramID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUserByTelegramID(ctx, req.(*GetUserByTelegramIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetUsersById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUsersById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUsersById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUsersById(ctx, req.(*GetUsersByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetUsersByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUsersByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUsersByFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUsersByFilter(ctx, req.(*GetUsersByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TelegramNotificationServiceServer).ExportUsers(m, &telegramNotificationServiceExportUsersServer{stream})
+}
+
+type TelegramNotificationService_ExportUsersServer interface {
+	Send(*ExportUsersResponse) error
+	grpc.ServerStream
+}
+
+type telegramNotificationServiceExportUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *telegramNotificationServiceExportUsersServer) Send(m *ExportUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TelegramNotificationService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_EditUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).EditUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/EditUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).EditUser(ctx, req.(*EditUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TelegramNotificationServiceServer).ImportUsers(&telegramNotificationServiceImportUsersServer{stream})
+}
+
+type TelegramNotificationService_ImportUsersServer interface {
+	SendAndClose(*ImportUsersResponse) error
+	Recv() (*ImportUsersRequest, error)
+	grpc.ServerStream
+}
+
+type telegramNotificationServiceImportUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *telegramNotificationServiceImportUsersServer) SendAndClose(m *ImportUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *telegramNotificationServiceImportUsersServer) Recv() (*ImportUsersRequest, error) {
+	m := new(ImportUsersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TelegramNotificationService_BatchCreateUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).BatchCreateUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/BatchCreateUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).BatchCreateUsers(ctx, req.(*BatchCreateUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_BatchEditUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchEditUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).BatchEditUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/BatchEditUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).BatchEditUsers(ctx, req.(*BatchEditUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_BatchDeleteUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).BatchDeleteUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/BatchDeleteUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).BatchDeleteUsers(ctx, req.(*BatchDeleteUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetUserDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetUserDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetUserDuplicates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetUserDuplicates(ctx, req.(*GetUserDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/PurgeUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).PurgeUser(ctx, req.(*PurgeUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/EraseUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetGroups(ctx, req.(*GetGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_EditGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).EditGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/EditGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).EditGroup(ctx, req.(*EditGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_RenameGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).RenameGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/RenameGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).RenameGroup(ctx, req.(*RenameGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_MergeGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).MergeGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/MergeGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).MergeGroups(ctx, req.(*MergeGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_AddUserToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserToGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).AddUserToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/AddUserToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).AddUserToGroup(ctx, req.(*AddUserToGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_RemoveUserFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserFromGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).RemoveUserFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/RemoveUserFromGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).RemoveUserFromGroup(ctx, req.(*RemoveUserFromGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetGroupTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetGroupTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetGroupTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetGroupTree(ctx, req.(*GetGroupTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_AddGroupModerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGroupModeratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).AddGroupModerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/AddGroupModerator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).AddGroupModerator(ctx, req.(*AddGroupModeratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_RemoveGroupModerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupModeratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).RemoveGroupModerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/RemoveGroupModerator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).RemoveGroupModerator(ctx, req.(*RemoveGroupModeratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_CreateApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).CreateApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/CreateApiClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).CreateApiClient(ctx, req.(*CreateApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetApiClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetApiClient(ctx, req.(*GetApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetApiClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetApiClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetApiClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetApiClients(ctx, req.(*GetApiClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_EditApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).EditApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/EditApiClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).EditApiClient(ctx, req.(*EditApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_DeleteApiClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApiClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).DeleteApiClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/DeleteApiClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).DeleteApiClient(ctx, req.(*DeleteApiClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramNotificationService_GetClientUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetClientUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetClientUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetClientUsage(ctx, req.(*GetClientUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *telegramNotificationServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
-	out := new(CreateUserResponse)
-	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/CreateUser", in, out, opts...)
-	if err != nil {
+func _TelegramNotificationService_CreateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopicRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).CreateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/CreateTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).CreateTopic(ctx, req.(*CreateTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *telegramNotificationServiceClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
-	out := new(GetGroupsResponse)
-	err := c.cc.Invoke(ctx, "/notification.v1.telegram_notification_service/GetGroups", in, out, opts...)
-	if err != nil {
+func _TelegramNotificationService_GetTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// TelegramNotificationServiceServer is the server API for TelegramNotificationService service.
-// All implementations must embed UnimplementedTelegramNotificationServiceServer
-// for forward compatibility
-type TelegramNotificationServiceServer interface {
-	SendNotification(context.Context, *SendNotificationRequest) (*SendNotificationResponse, error)
-	GetNotification(context.Context, *GetNotificationRequest) (*GetNotificationResponse, error)
-	GetNotifications(context.Context, *GetNotificationsRequest) (*GetNotificationsResponse, error)
-	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
-	GetUserByTelegramID(context.Context, *GetUserByTelegramIDRequest) (*GetUserByTelegramIDResponse, error)
-	GetUsersById(context.Context, *GetUsersByIdRequest) (*GetUsersByIdResponse, error)
-	GetUsersByFilter(context.Context, *GetUsersByFilterRequest) (*GetUsersByFilterResponse, error)
-	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
-	EditUser(context.Context, *EditUserRequest) (*EditUserResponse, error)
-	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
-	GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error)
-	mustEmbedUnimplementedTelegramNotificationServiceServer()
-}
-
-// UnimplementedTelegramNotificationServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedTelegramNotificationServiceServer struct {
-}
-
-func (UnimplementedTelegramNotificationServiceServer) SendNotification(context.Context, *SendNotificationRequest) (*SendNotificationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendNotification not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetNotification(context.Context, *GetNotificationRequest) (*GetNotificationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNotification not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetNotifications(context.Context, *GetNotificationsRequest) (*GetNotificationsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNotifications not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetUserByTelegramID(context.Context, *GetUserByTelegramIDRequest) (*GetUserByTelegramIDResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserByTelegramID not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetUsersById(context.Context, *GetUsersByIdRequest) (*GetUsersByIdResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUsersById not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetUsersByFilter(context.Context, *GetUsersByFilterRequest) (*GetUsersByFilterResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByFilter not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) EditUser(context.Context, *EditUserRequest) (*EditUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditUser not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGroups not implemented")
-}
-func (UnimplementedTelegramNotificationServiceServer) mustEmbedUnimplementedTelegramNotificationServiceServer() {
-}
-
-// UnsafeTelegramNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TelegramNotificationServiceServer will
-// result in compilation errors.
-type UnsafeTelegramNotificationServiceServer interface {
-	mustEmbedUnimplementedTelegramNotificationServiceServer()
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetTopic(ctx, req.(*GetTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterTelegramNotificationServiceServer(s grpc.ServiceRegistrar, srv TelegramNotificationServiceServer) {
-	s.RegisterService(&TelegramNotificationService_ServiceDesc, srv)
+func _TelegramNotificationService_GetTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramNotificationServiceServer).GetTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.v1.telegram_notification_service/GetTopics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramNotificationServiceServer).GetTopics(ctx, req.(*GetTopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_SendNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendNotificationRequest)
+func _TelegramNotificationService_EditTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditTopicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).SendNotification(ctx, in)
+		return srv.(TelegramNotificationServiceServer).EditTopic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/SendNotification",
+		FullMethod: "/notification.v1.telegram_notification_service/EditTopic",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).SendNotification(ctx, req.(*SendNotificationRequest))
+		return srv.(TelegramNotificationServiceServer).EditTopic(ctx, req.(*EditTopicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNotificationRequest)
+func _TelegramNotificationService_DeleteTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTopicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetNotification(ctx, in)
+		return srv.(TelegramNotificationServiceServer).DeleteTopic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetNotification",
+		FullMethod: "/notification.v1.telegram_notification_service/DeleteTopic",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetNotification(ctx, req.(*GetNotificationRequest))
+		return srv.(TelegramNotificationServiceServer).DeleteTopic(ctx, req.(*DeleteTopicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNotificationsRequest)
+func _TelegramNotificationService_SubscribeToTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeToTopicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetNotifications(ctx, in)
+		return srv.(TelegramNotificationServiceServer).SubscribeToTopic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetNotifications",
+		FullMethod: "/notification.v1.telegram_notification_service/SubscribeToTopic",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetNotifications(ctx, req.(*GetNotificationsRequest))
+		return srv.(TelegramNotificationServiceServer).SubscribeToTopic(ctx, req.(*SubscribeToTopicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
+func _TelegramNotificationService_UnsubscribeFromTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeFromTopicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetUser(ctx, in)
+		return srv.(TelegramNotificationServiceServer).UnsubscribeFromTopic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetUser",
+		FullMethod: "/notification.v1.telegram_notification_service/UnsubscribeFromTopic",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetUser(ctx, req.(*GetUserRequest))
+		return srv.(TelegramNotificationServiceServer).UnsubscribeFromTopic(ctx, req.(*UnsubscribeFromTopicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetUserByTelegramID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserByTelegramIDRequest)
+func _TelegramNotificationService_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetUserByTelegramID(ctx, in)
+		return srv.(TelegramNotificationServiceServer).GetAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetUserByTelegramID",
+		FullMethod: "/notification.v1.telegram_notification_service/GetAuditLog",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetUserByTelegramID(ctx, req.(*GetUserByTelegramIDRequest))
+		return srv.(TelegramNotificationServiceServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetUsersById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUsersByIdRequest)
+func _TelegramNotificationService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetUsersById(ctx, in)
+		return srv.(TelegramNotificationServiceServer).CreateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetUsersById",
+		FullMethod: "/notification.v1.telegram_notification_service/CreateWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetUsersById(ctx, req.(*GetUsersByIdRequest))
+		return srv.(TelegramNotificationServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetUsersByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUsersByFilterRequest)
+func _TelegramNotificationService_GetWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetUsersByFilter(ctx, in)
+		return srv.(TelegramNotificationServiceServer).GetWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetUsersByFilter",
+		FullMethod: "/notification.v1.telegram_notification_service/GetWebhooks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetUsersByFilter(ctx, req.(*GetUsersByFilterRequest))
+		return srv.(TelegramNotificationServiceServer).GetWebhooks(ctx, req.(*GetWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserRequest)
+func _TelegramNotificationService_EditWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).DeleteUser(ctx, in)
+		return srv.(TelegramNotificationServiceServer).EditWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/DeleteUser",
+		FullMethod: "/notification.v1.telegram_notification_service/EditWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+		return srv.(TelegramNotificationServiceServer).EditWebhook(ctx, req.(*EditWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_EditUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EditUserRequest)
+func _TelegramNotificationService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).EditUser(ctx, in)
+		return srv.(TelegramNotificationServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/EditUser",
+		FullMethod: "/notification.v1.telegram_notification_service/DeleteWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).EditUser(ctx, req.(*EditUserRequest))
+		return srv.(TelegramNotificationServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _TelegramNotificationService_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookDeliveriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).CreateUser(ctx, in)
+		return srv.(TelegramNotificationServiceServer).GetWebhookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/CreateUser",
+		FullMethod: "/notification.v1.telegram_notification_service/GetWebhookDeliveries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(TelegramNotificationServiceServer).GetWebhookDeliveries(ctx, req.(*GetWebhookDeliveriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TelegramNotificationService_GetGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGroupsRequest)
+func _TelegramNotificationService_RetryWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryWebhookDeliveryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TelegramNotificationServiceServer).GetGroups(ctx, in)
+		return srv.(TelegramNotificationServiceServer).RetryWebhookDelivery(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/notification.v1.telegram_notification_service/GetGroups",
+		FullMethod: "/notification.v1.telegram_notification_service/RetryWebhookDelivery",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TelegramNotificationServiceServer).GetGroups(ctx, req.(*GetGroupsRequest))
+		return srv.(TelegramNotificationServiceServer).RetryWebhookDelivery(ctx, req.(*RetryWebhookDeliveryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -428,6 +1985,22 @@ var TelegramNotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNotifications",
 			Handler:    _TelegramNotificationService_GetNotifications_Handler,
 		},
+		{
+			MethodName: "SearchNotifications",
+			Handler:    _TelegramNotificationService_SearchNotifications_Handler,
+		},
+		{
+			MethodName: "GetUserInbox",
+			Handler:    _TelegramNotificationService_GetUserInbox_Handler,
+		},
+		{
+			MethodName: "GetNotificationStats",
+			Handler:    _TelegramNotificationService_GetNotificationStats_Handler,
+		},
+		{
+			MethodName: "GetNotificationEngagement",
+			Handler:    _TelegramNotificationService_GetNotificationEngagement_Handler,
+		},
 		{
 			MethodName: "GetUser",
 			Handler:    _TelegramNotificationService_GetUser_Handler,
@@ -456,11 +2029,183 @@ var TelegramNotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateUser",
 			Handler:    _TelegramNotificationService_CreateUser_Handler,
 		},
+		{
+			MethodName: "BatchCreateUsers",
+			Handler:    _TelegramNotificationService_BatchCreateUsers_Handler,
+		},
+		{
+			MethodName: "BatchEditUsers",
+			Handler:    _TelegramNotificationService_BatchEditUsers_Handler,
+		},
+		{
+			MethodName: "BatchDeleteUsers",
+			Handler:    _TelegramNotificationService_BatchDeleteUsers_Handler,
+		},
+		{
+			MethodName: "GetUserDuplicates",
+			Handler:    _TelegramNotificationService_GetUserDuplicates_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _TelegramNotificationService_RestoreUser_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _TelegramNotificationService_PurgeUser_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _TelegramNotificationService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _TelegramNotificationService_EraseUserData_Handler,
+		},
 		{
 			MethodName: "GetGroups",
 			Handler:    _TelegramNotificationService_GetGroups_Handler,
 		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _TelegramNotificationService_GetGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _TelegramNotificationService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "EditGroup",
+			Handler:    _TelegramNotificationService_EditGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _TelegramNotificationService_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "RenameGroup",
+			Handler:    _TelegramNotificationService_RenameGroup_Handler,
+		},
+		{
+			MethodName: "MergeGroups",
+			Handler:    _TelegramNotificationService_MergeGroups_Handler,
+		},
+		{
+			MethodName: "AddUserToGroup",
+			Handler:    _TelegramNotificationService_AddUserToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveUserFromGroup",
+			Handler:    _TelegramNotificationService_RemoveUserFromGroup_Handler,
+		},
+		{
+			MethodName: "GetGroupTree",
+			Handler:    _TelegramNotificationService_GetGroupTree_Handler,
+		},
+		{
+			MethodName: "AddGroupModerator",
+			Handler:    _TelegramNotificationService_AddGroupModerator_Handler,
+		},
+		{
+			MethodName: "RemoveGroupModerator",
+			Handler:    _TelegramNotificationService_RemoveGroupModerator_Handler,
+		},
+		{
+			MethodName: "CreateApiClient",
+			Handler:    _TelegramNotificationService_CreateApiClient_Handler,
+		},
+		{
+			MethodName: "GetApiClient",
+			Handler:    _TelegramNotificationService_GetApiClient_Handler,
+		},
+		{
+			MethodName: "GetApiClients",
+			Handler:    _TelegramNotificationService_GetApiClients_Handler,
+		},
+		{
+			MethodName: "EditApiClient",
+			Handler:    _TelegramNotificationService_EditApiClient_Handler,
+		},
+		{
+			MethodName: "DeleteApiClient",
+			Handler:    _TelegramNotificationService_DeleteApiClient_Handler,
+		},
+		{
+			MethodName: "GetClientUsage",
+			Handler:    _TelegramNotificationService_GetClientUsage_Handler,
+		},
+		{
+			MethodName: "CreateTopic",
+			Handler:    _TelegramNotificationService_CreateTopic_Handler,
+		},
+		{
+			MethodName: "GetTopic",
+			Handler:    _TelegramNotificationService_GetTopic_Handler,
+		},
+		{
+			MethodName: "GetTopics",
+			Handler:    _TelegramNotificationService_GetTopics_Handler,
+		},
+		{
+			MethodName: "EditTopic",
+			Handler:    _TelegramNotificationService_EditTopic_Handler,
+		},
+		{
+			MethodName: "DeleteTopic",
+			Handler:    _TelegramNotificationService_DeleteTopic_Handler,
+		},
+		{
+			MethodName: "SubscribeToTopic",
+			Handler:    _TelegramNotificationService_SubscribeToTopic_Handler,
+		},
+		{
+			MethodName: "UnsubscribeFromTopic",
+			Handler:    _TelegramNotificationService_UnsubscribeFromTopic_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _TelegramNotificationService_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _TelegramNotificationService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhooks",
+			Handler:    _TelegramNotificationService_GetWebhooks_Handler,
+		},
+		{
+			MethodName: "EditWebhook",
+			Handler:    _TelegramNotificationService_EditWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _TelegramNotificationService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhookDeliveries",
+			Handler:    _TelegramNotificationService_GetWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RetryWebhookDelivery",
+			Handler:    _TelegramNotificationService_RetryWebhookDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNotification",
+			Handler:       _TelegramNotificationService_WatchNotification_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _TelegramNotificationService_ExportUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _TelegramNotificationService_ImportUsers_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/telegram_notification.proto",
 }
@@ -1,16 +1,31 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"telegram-notification-api/internal/app"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/certs"
 	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/commands"
 	projectConfig "telegram-notification-api/internal/config"
 	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/events"
+	"telegram-notification-api/internal/links"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/ratelimit"
+	"telegram-notification-api/internal/stats"
 	"telegram-notification-api/internal/storage"
+	"telegram-notification-api/internal/webhooks"
 )
 
 var logger *slog.Logger
@@ -22,34 +37,138 @@ func init() {
 func main() {
 	config, err := projectConfig.NewConfig(projectConfig.LocalEnv)
 	if err != nil {
-		logger.Error("can't create config: ", err)
+		logger.Error("can't create config", slog.Any("err", err))
 		return
 	}
 
-	s, err := storage.NewStorage(config.MustGetDatabaseConnectionString())
+	s, err := storage.NewStorage(config.MustGetDatabaseConnectionString(), logger)
 	if err != nil {
-		logger.Error("can't create storage: ", err)
+		logger.Error("can't create storage", slog.Any("err", err))
 		return
 	}
 
 	c, err := clients.NewClients(config)
 	if err != nil {
-		logger.Error("can't create clients: ", err)
+		logger.Error("can't create clients", slog.Any("err", err))
 		return
 	}
 
 	d := dao.NewDAO(s)
-	a := app.New(logger, d, c, config.MustGetServerHost(), config.MustGetServerPort())
+
+	var authenticator *auth.Authenticator
+	if config.MustGetAuthEnabled() {
+		var jwtVerifier *auth.JWTVerifier
+		if jwksFile := config.MustGetAuthJWKSFile(); jwksFile != "" {
+			jwtVerifier, err = auth.NewJWTVerifier(
+				jwksFile,
+				config.MustGetAuthJWTIssuer(),
+				config.MustGetAuthJWTAudience(),
+			)
+			if err != nil {
+				logger.Error("can't create jwt verifier", slog.Any("err", err))
+				return
+			}
+		}
+		authenticator = auth.NewAuthenticator(d, jwtVerifier)
+	}
+
+	limiter := ratelimit.NewLimiter(config.MustGetRateLimits(), d)
+
+	paginator, err := pagination.NewPaginator(config.MustGetPagination())
+	if err != nil {
+		logger.Error("can't create paginator", slog.Any("err", err))
+		return
+	}
+
+	linksCfg := config.MustGetLinks()
+	tracker, err := links.NewTracker(linksCfg)
+	if err != nil {
+		logger.Error("can't create link tracker", slog.Any("err", err))
+		return
+	}
+
+	dispatcher, err := webhooks.NewDispatcher(config.MustGetWebhooks(), d, logger)
+	if err != nil {
+		logger.Error("can't create webhook dispatcher", slog.Any("err", err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if botCfg := config.MustGetBot(); botCfg.CommandsEnabled {
+		commands.Register(logger, c.TelegramClient(), d, botCfg)
+		go c.TelegramClient().Start(ctx)
+	}
+
+	go stats.NewRefresher(config.MustGetStats(), d, logger).Run(ctx)
+	go dispatcher.Run(ctx)
+
+	bus := events.NewBus(d, logger)
+	go func() {
+		if err := bus.Listen(ctx, config.MustGetDatabaseConnectionString()); err != nil {
+			logger.Error("can't listen to delivery events", slog.Any("err", err))
+		}
+	}()
+
+	var tlsConfig *tls.Config
+	if tlsCfg := config.MustGetTLS(); tlsCfg.Enabled {
+		reloader, err := certs.NewReloader(tlsCfg, logger)
+		if err != nil {
+			logger.Error("can't load tls files", slog.Any("err", err))
+			return
+		}
+		go reloader.Run(ctx)
+		tlsConfig = reloader.ServerConfig()
+	}
+
+	a, err := app.New(
+		logger,
+		d,
+		c,
+		authenticator,
+		limiter,
+		paginator,
+		tracker,
+		bus,
+		tlsConfig,
+		config.MustGetServerHost(),
+		config.MustGetServerPort(),
+		config.MustGetGateway(),
+		config.MustGetImport(),
+	)
+	if err != nil {
+		logger.Error("can't create app", slog.Any("err", err))
+		return
+	}
 	go func() {
 		if err = a.Run(); err != nil {
-			logger.Error("can't run app: ", err)
+			logger.Error("can't run app", slog.Any("err", err))
 			return
 		}
 	}()
 
+	var linkServer *http.Server
+	if tracker.Enabled() {
+		linkServer = &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", linksCfg.Host, linksCfg.Port),
+			Handler:           links.NewHandler(tracker, d, logger),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			logger.Info("link server started", slog.String("addr", linkServer.Addr))
+			if err := linkServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("can't run link server", slog.Any("err", err))
+			}
+		}()
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	<-stop
+	if linkServer != nil {
+		logger.Info("stopping link server", slog.Any("err", linkServer.Shutdown(context.Background())))
+	}
 	a.Stop()
 	logger.Info("Gracefully stopped")
 }
@@ -6,10 +6,14 @@ require (
 	github.com/Masterminds/squirrel v1.5.2
 	github.com/elgris/stom v0.0.0-20160204063428-05ccb51a70bb
 	github.com/go-telegram/bot v1.2.2
+	github.com/golang-jwt/jwt/v5 v5.2.1
 	github.com/grpc-ecosystem/go-grpc-middleware/v2 v2.0.0
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.15.2
 	github.com/jmoiron/sqlx v1.3.4
 	github.com/lib/pq v1.10.6
 	github.com/spf13/viper v1.12.0
+	golang.org/x/time v0.5.0
+	google.golang.org/genproto v0.0.0-20230223222841-637eb2293923
 	google.golang.org/grpc v1.53.0
 	google.golang.org/protobuf v1.33.0
 )
@@ -33,7 +37,6 @@ require (
 	golang.org/x/net v0.14.0 // indirect
 	golang.org/x/sys v0.11.0 // indirect
 	golang.org/x/text v0.12.0 // indirect
-	google.golang.org/genproto v0.0.0-20230110181048-76db0878b65f // indirect
 	gopkg.in/ini.v1 v1.66.4 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -2,18 +2,31 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/authz"
 	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/config"
 	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/events"
+	"telegram-notification-api/internal/links"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/ratelimit"
 	"telegram-notification-api/internal/server"
 )
 
@@ -23,6 +36,10 @@ type App struct {
 	dao        dao.DAO
 	port       int
 	host       string
+
+	// gateway and gatewayServer are nil if the http api is disabled
+	gateway       *gateway
+	gatewayServer *http.Server
 }
 
 func InterceptorLogger(l *slog.Logger) logging.Logger {
@@ -31,7 +48,27 @@ func InterceptorLogger(l *slog.Logger) logging.Logger {
 	})
 }
 
-func New(log *slog.Logger, dao dao.DAO, clients clients.Clients, host string, port int) *App {
+// New creates the gRPC application, authenticator may be nil to serve unauthenticated calls
+// and tlsConfig may be nil to listen without tls.
+// Limits of limiter are enforced only if they are enabled.
+// If gatewayCfg is enabled, the http/json api is served on host too.
+func New(
+	log *slog.Logger,
+	dao dao.DAO,
+	clients clients.Clients,
+	authenticator *auth.Authenticator,
+	limiter *ratelimit.Limiter,
+	paginator *pagination.Paginator,
+	tracker *links.Tracker,
+	bus *events.Bus,
+	tlsConfig *tls.Config,
+	host string,
+	port int,
+	gatewayCfg config.Gateway,
+	importCfg config.Import,
+) (*App, error) {
+	const op = "grpcapp.New"
+
 	loggingOpts := []logging.Option{
 		logging.WithLogOnEvents(
 			logging.PayloadReceived, logging.PayloadSent,
@@ -46,19 +83,61 @@ func New(log *slog.Logger, dao dao.DAO, clients clients.Clients, host string, po
 		}),
 	}
 
-	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		recovery.UnaryServerInterceptor(recoveryOpts...),
+		audit.UnaryServerInterceptor(),
 		logging.UnaryServerInterceptor(InterceptorLogger(log), loggingOpts...),
-	))
-	desc.RegisterTelegramNotificationServiceServer(gRPCServer, server.NewServer(dao, clients))
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		recovery.StreamServerInterceptor(recoveryOpts...),
+		audit.StreamServerInterceptor(),
+		logging.StreamServerInterceptor(InterceptorLogger(log), loggingOpts...),
+	}
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, authenticator.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, authenticator.StreamServerInterceptor())
+	}
+	if limiter.Enabled() {
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors, authz.UnaryServerInterceptor(dao))
+	streamInterceptors = append(streamInterceptors, authz.StreamServerInterceptor(dao))
 
-	return &App{
-		log:        log,
-		gRPCServer: gRPCServer,
-		port:       port,
-		host:       host,
-		dao:        dao,
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	}
+	srv := server.NewServer(dao, clients, limiter, paginator, tracker, bus, importCfg)
+
+	a := &App{
+		log:  log,
+		port: port,
+		host: host,
+		dao:  dao,
+	}
+	if gatewayCfg.Enabled {
+		// the gateway is connected in memory, so its server doesn't use tls
+		g, err := newGateway(serverOpts, srv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		a.gateway = g
+		a.gatewayServer = &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", host, gatewayCfg.Port),
+			Handler:           g.handler,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	a.gRPCServer = grpc.NewServer(serverOpts...)
+	desc.RegisterTelegramNotificationServiceServer(a.gRPCServer, srv)
+
+	return a, nil
 }
 
 func (a *App) MustRun() {
@@ -77,6 +156,10 @@ func (a *App) Run() error {
 
 	a.log.Info("grpc server started", slog.String("addr", l.Addr().String()))
 
+	if a.gateway != nil {
+		go a.runGateway()
+	}
+
 	if err = a.gRPCServer.Serve(l); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -84,9 +167,43 @@ func (a *App) Run() error {
 	return nil
 }
 
+// runGateway serves the http api until the app is stopped
+func (a *App) runGateway() {
+	const op = "grpcapp.runGateway"
+	log := a.log.With(slog.String("op", op))
+
+	go func() {
+		if err := a.gateway.serve(); err != nil {
+			log.Error("can't serve gateway calls", slog.Any("err", err))
+		}
+	}()
+
+	l, err := net.Listen("tcp", a.gatewayServer.Addr)
+	if err != nil {
+		log.Error("can't listen gateway address", slog.Any("err", err))
+		return
+	}
+
+	log.Info("http gateway started", slog.String("addr", l.Addr().String()))
+	if a.gatewayServer.TLSConfig != nil {
+		// certificates come from TLSConfig, ServeTLS also enables http/2
+		err = a.gatewayServer.ServeTLS(l, "", "")
+	} else {
+		err = a.gatewayServer.Serve(l)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error("can't run http gateway", slog.Any("err", err))
+	}
+}
+
 func (a *App) Stop() {
 	const op = "grpcapp.Stop"
 
+	if a.gateway != nil {
+		a.log.With(slog.String("op", op)).
+			Info("stopping http gateway", slog.Any("err", a.gatewayServer.Shutdown(context.Background())))
+		a.gateway.stop()
+	}
 	a.log.With(slog.String("op", op)).
 		Info("stopping gRPC server", slog.Int("port", a.port))
 	a.log.With(slog.String("op", op)).
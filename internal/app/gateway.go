@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/utils/stream"
+)
+
+const (
+	// gatewayBufferSize is the buffer of the in-memory connection between the gateway and the grpc server
+	gatewayBufferSize = 1 << 20
+	// gatewayPeerMetadataKey is set by the gateway to the remote address of the http connection,
+	// http callers can't pass it, unlike x-forwarded-for
+	gatewayPeerMetadataKey = "x-gateway-peer"
+	// gatewayClientCertMetadataKey is set by the gateway to the client certificate verified
+	// during the tls handshake of the http connection, base64 encoded der
+	gatewayClientCertMetadataKey = "x-gateway-client-cert"
+	// openAPIPath serves the spec of the http api
+	openAPIPath = "/openapi.json"
+)
+
+// forwardedHeaders are passed from http requests to grpc calls as metadata with the same keys
+var forwardedHeaders = map[string]string{
+	textproto.CanonicalMIMEHeaderKey(auth.ApiKeyMetadataKey):     auth.ApiKeyMetadataKey,
+	textproto.CanonicalMIMEHeaderKey(audit.RequestIDMetadataKey): audit.RequestIDMetadataKey,
+}
+
+// droppedMetadataKeys can't be passed by http callers, not even with Grpc-Metadata- headers
+var droppedMetadataKeys = []string{
+	authz.ActorMetadataKey,
+	gatewayPeerMetadataKey,
+	gatewayClientCertMetadataKey,
+}
+
+// gateway translates http/json requests to calls of the grpc server.
+// Calls go through an in-memory connection to a server with the same interceptors as the public one,
+// so they are authenticated, limited and audited the same way and grpc errors map to http statuses.
+type gateway struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+	handler  http.Handler
+}
+
+func newGateway(serverOpts []grpc.ServerOption, srv desc.TelegramNotificationServiceServer) (*gateway, error) {
+	listener := bufconn.Listen(gatewayBufferSize)
+	serverOpts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(gatewayPeerUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(gatewayPeerStreamInterceptor()),
+	}, serverOpts...)
+	server := grpc.NewServer(serverOpts...)
+	desc.RegisterTelegramNotificationServiceServer(server, srv)
+
+	conn, err := grpc.Dial(
+		"passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithMetadata(gatewayMetadata),
+	)
+	if err = desc.RegisterTelegramNotificationServiceHandler(context.Background(), gatewayMux, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(openAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(desc.OpenAPISpec)
+	})
+	mux.Handle("/", gatewayMux)
+
+	return &gateway{
+		listener: listener,
+		server:   server,
+		conn:     conn,
+		handler:  mux,
+	}, nil
+}
+
+// serve runs the grpc server behind the gateway, it returns once the server is stopped
+func (g *gateway) serve() error {
+	return g.server.Serve(g.listener)
+}
+
+func (g *gateway) stop() {
+	_ = g.conn.Close()
+	g.server.GracefulStop()
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	if metadataKey, ok := forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+		return metadataKey, true
+	}
+	metadataKey, ok := runtime.DefaultHeaderMatcher(key)
+	if !ok || slices.Contains(droppedMetadataKeys, strings.ToLower(metadataKey)) {
+		return "", false
+	}
+	return metadataKey, true
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == audit.RequestIDMetadataKey {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// gatewayMetadata passes what the gateway knows about the http connection to the grpc call
+func gatewayMetadata(_ context.Context, r *http.Request) metadata.MD {
+	md := metadata.Pairs(gatewayPeerMetadataKey, r.RemoteAddr)
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		md.Set(gatewayClientCertMetadataKey, base64.StdEncoding.EncodeToString(r.TLS.VerifiedChains[0][0].Raw))
+	}
+	return md
+}
+
+// gatewayPeer replaces the in-memory peer of the call with the http caller: its address,
+// so unauthenticated calls are limited per caller, and its verified client certificate,
+// so the authenticator accepts it like on the grpc port. Both are set only by the gateway,
+// the server is reachable only through it, so they can be trusted.
+func gatewayPeer(ctx context.Context) context.Context {
+	p := &peer.Peer{}
+	if inMemory, ok := peer.FromContext(ctx); ok {
+		*p = *inMemory
+	}
+	if ip := gatewayPeerIP(ctx); ip != nil {
+		p.Addr = &net.TCPAddr{IP: ip}
+	}
+	if cert := gatewayClientCert(ctx); cert != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+func gatewayPeerIP(ctx context.Context) net.IP {
+	values := metadata.ValueFromIncomingContext(ctx, gatewayPeerMetadataKey)
+	if len(values) != 1 {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(values[0])
+	if err != nil {
+		host = values[0]
+	}
+	return net.ParseIP(host)
+}
+
+func gatewayClientCert(ctx context.Context) *x509.Certificate {
+	values := metadata.ValueFromIncomingContext(ctx, gatewayClientCertMetadataKey)
+	if len(values) != 1 {
+		return nil
+	}
+	der, err := base64.StdEncoding.DecodeString(values[0])
+	if err != nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+func gatewayPeerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(gatewayPeer(ctx), req)
+	}
+}
+
+func gatewayPeerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, stream.WithContext(ss, gatewayPeer(ss.Context())))
+	}
+}
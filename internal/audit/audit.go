@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"google.golang.org/grpc"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+)
+
+// Types of audited targets
+const (
+	TargetUser         = "user"
+	TargetGroup        = "group"
+	TargetTopic        = "topic"
+	TargetApiClient    = "api_client"
+	TargetNotification = "notification"
+	TargetWebhook      = "webhook"
+)
+
+// Record writes an audit record of the change made by the current call.
+// d must be bound to the transaction of the change, so the record is saved only together with it.
+// before is nil for created targets and after is nil for deleted ones.
+func Record(
+	ctx context.Context,
+	d dao.DAO,
+	targetType string,
+	targetIDs []int64,
+	before interface{},
+	after interface{},
+) error {
+	const op = "audit.Record"
+
+	changes, err := json.Marshal(Diff(before, after))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	record := dao.AuditLogTable{
+		TargetType: targetType,
+		TargetIDs:  targetIDs,
+		Changes:    changes,
+		RequestID:  RequestIDFromContext(ctx),
+	}
+	if method, ok := grpc.Method(ctx); ok {
+		record.Method = path.Base(method)
+	}
+	if actor, ok := authz.ActorFromContext(ctx); ok {
+		record.ActorID = sql.NullInt64{Int64: actor.Id, Valid: true}
+	}
+	if p, ok := auth.FromContext(ctx); ok {
+		if !record.ActorID.Valid && p.UserID > 0 {
+			record.ActorID = sql.NullInt64{Int64: p.UserID, Valid: true}
+		}
+		if p.Method == auth.MethodApiKey {
+			record.ApiClientID = sql.NullInt64{Int64: p.ApiClientID, Valid: true}
+		}
+	}
+
+	if err = d.NewAuditLogQuery().CreateAuditRecord(ctx, record); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
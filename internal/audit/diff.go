@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+
+	"telegram-notification-api/internal/dao"
+)
+
+// redactedFields are recorded as changed without their values
+var redactedFields = map[string]struct{}{
+	"key_hash": {},
+	"secret":   {},
+}
+
+// Change is a change of a single field
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff returns fields which differ between before and after.
+// Both are structs with db tags or maps of fields, nil stands for a missing target.
+func Diff(before, after interface{}) map[string]Change {
+	beforeFields := fields(before)
+	afterFields := fields(after)
+
+	changes := make(map[string]Change)
+	for name, b := range beforeFields {
+		a, ok := afterFields[name]
+		if ok && reflect.DeepEqual(a, b) {
+			continue
+		}
+		changes[name] = Change{Before: b, After: a}
+	}
+	for name, a := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = Change{After: a}
+		}
+	}
+
+	for name, change := range changes {
+		if _, ok := redactedFields[name]; !ok {
+			continue
+		}
+		if change.Before != nil {
+			change.Before = dao.RedactedValue
+		}
+		if change.After != nil {
+			change.After = dao.RedactedValue
+		}
+		changes[name] = change
+	}
+	return changes
+}
+
+func fields(v interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	if v == nil {
+		return result
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		for name, value := range m {
+			result[name] = plainValue(reflect.ValueOf(value))
+		}
+		return result
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return result
+		}
+		rv = rv.Elem()
+	}
+	collectFields(rv, result)
+	return result
+}
+
+func collectFields(rv reflect.Value, result map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			collectFields(rv.Field(i), result)
+			continue
+		}
+		if tag == "" || tag == "-" {
+			continue
+		}
+		result[tag] = plainValue(rv.Field(i))
+	}
+}
+
+// plainValue converts sql types to values which are comparable and encodable to json
+func plainValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	value := v.Interface()
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	if valuer, ok := value.(driver.Valuer); ok && v.Kind() != reflect.Slice {
+		plain, err := valuer.Value()
+		if err != nil {
+			return value
+		}
+		if t, ok := plain.(time.Time); ok {
+			return t.UTC().Format(time.RFC3339Nano)
+		}
+		return plain
+	}
+	return value
+}
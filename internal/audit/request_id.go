@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"telegram-notification-api/internal/utils/stream"
+)
+
+// RequestIDMetadataKey is a metadata key with id of the request,
+// it's generated if the client doesn't pass it and is returned in the response header
+const RequestIDMetadataKey = "x-request-id"
+
+type requestIDKey struct{}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		requestID := requestIDFromMetadata(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+		return handler(context.WithValue(ctx, requestIDKey{}, requestID), req)
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		requestID := requestIDFromMetadata(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIDMetadataKey, requestID))
+		return handler(srv, stream.WithContext(ss, context.WithValue(ss.Context(), requestIDKey{}, requestID)))
+	}
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, RequestIDMetadataKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	apiKeyPrefix = "tna_"
+	apiKeyBytes  = 32
+)
+
+// GenerateApiKey returns a new random api key and its hash to store
+func GenerateApiKey() (key string, hash string, err error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, HashApiKey(key), nil
+}
+
+// HashApiKey returns the hash api keys are stored and looked up by.
+// Keys are long random strings, so a fast hash without salt is enough.
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/utils/stream"
+)
+
+const (
+	// ApiKeyMetadataKey is a metadata key for api keys issued by CreateApiClient
+	ApiKeyMetadataKey = "x-api-key"
+	// AuthorizationMetadataKey carries jwt as "Bearer <token>"
+	AuthorizationMetadataKey = "authorization"
+
+	bearerPrefix = "bearer "
+)
+
+// Authenticator resolves the principal of every call from an api key or a jwt
+type Authenticator struct {
+	dao dao.DAO
+	// jwt is nil if jwt authentication is not configured
+	jwt *JWTVerifier
+}
+
+func NewAuthenticator(dao dao.DAO, jwt *JWTVerifier) *Authenticator {
+	return &Authenticator{
+		dao: dao,
+		jwt: jwt,
+	}
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, stream.WithContext(ss, ctx))
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	if key := firstMetadataValue(ctx, ApiKeyMetadataKey); key != "" {
+		p, err := a.authenticateApiKey(ctx, key)
+		if err != nil {
+			return ctx, err
+		}
+		return NewContext(ctx, p), nil
+	}
+
+	authorization := firstMetadataValue(ctx, AuthorizationMetadataKey)
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		if a.jwt == nil {
+			return ctx, unauthenticated("jwt authentication is not configured")
+		}
+		p, err := a.jwt.Verify(authorization[len(bearerPrefix):])
+		if err != nil {
+			return ctx, unauthenticated("invalid token: " + err.Error())
+		}
+		return NewContext(ctx, p), nil
+	}
+
+	if subject, ok := clientCertSubject(ctx); ok {
+		return NewContext(ctx, Principal{
+			Method:  MethodClientCert,
+			Subject: subject,
+		}), nil
+	}
+
+	return ctx, unauthenticated("credentials are not provided, pass " + ApiKeyMetadataKey +
+		" or " + AuthorizationMetadataKey + " metadata or a client certificate")
+}
+
+// clientCertSubject returns the subject of the client certificate verified during the tls handshake
+func clientCertSubject(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	subject := tlsInfo.State.VerifiedChains[0][0].Subject
+	if subject.CommonName != "" {
+		return subject.CommonName, true
+	}
+	return subject.String(), true
+}
+
+func (a *Authenticator) authenticateApiKey(ctx context.Context, key string) (Principal, error) {
+	client, err := a.dao.NewApiClientQuery().GetApiClientByKeyHash(ctx, HashApiKey(key))
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return Principal{}, unauthenticated("invalid api key")
+	}
+	if err != nil {
+		return Principal{}, errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	return Principal{
+		Method:      MethodApiKey,
+		Subject:     client.Name,
+		ApiClientID: client.ID,
+		UserID:      client.UserID.Int64,
+	}, nil
+}
+
+func firstMetadataValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func unauthenticated(reason string) error {
+	return errors.NewNetworkError(codes.Unauthenticated, reason).ToGRPCError()
+}
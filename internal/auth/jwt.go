@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTVerifier validates tokens signed by keys from a local JWKS file
+type JWTVerifier struct {
+	keys   map[string]crypto.PublicKey
+	parser *jwt.Parser
+}
+
+func NewJWTVerifier(jwksFile string, issuer string, audience string) (*JWTVerifier, error) {
+	keys, err := loadJWKS(jwksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return &JWTVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+	}, nil
+}
+
+// Verify checks the token and returns its subject as a principal.
+// Numeric subjects are treated as user ids.
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	claims := jwt.RegisteredClaims{}
+	_, err := v.parser.ParseWithClaims(token, &claims, v.keyFunc)
+	if err != nil {
+		return Principal{}, err
+	}
+	if claims.Subject == "" {
+		return Principal{}, errors.New("token has no subject")
+	}
+
+	p := Principal{
+		Method:  MethodJWT,
+		Subject: claims.Subject,
+	}
+	if userID, err := strconv.ParseInt(claims.Subject, 10, 64); err == nil && userID > 0 {
+		p.UserID = userID
+	}
+	return p, nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
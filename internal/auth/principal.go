@@ -0,0 +1,34 @@
+package auth
+
+import "context"
+
+type Method string
+
+const (
+	MethodApiKey Method = "api_key"
+	MethodJWT    Method = "jwt"
+	// MethodClientCert is used for mutual tls, the subject is the client certificate subject
+	MethodClientCert Method = "client_cert"
+)
+
+// Principal is the authenticated caller
+type Principal struct {
+	Method Method
+	// Subject is the api client name, the jwt subject or the client certificate subject
+	Subject string
+	// ApiClientID is set for api key authentication
+	ApiClientID int64
+	// UserID is the user the caller acts on behalf of, 0 if the caller is not bound to a user
+	UserID int64
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
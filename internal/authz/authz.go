@@ -0,0 +1,225 @@
+package authz
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/utils/stream"
+)
+
+// ActorMetadataKey is a metadata key with id of the user performing the call.
+// Requests with sender_id use it as the actor instead.
+// Both are used only if authentication is disabled, authenticated callers act as the user of their principal.
+const ActorMetadataKey = "x-actor-id"
+
+type actorKey struct{}
+
+type senderRequest interface {
+	GetSenderId() int64
+}
+
+// ActorFromContext returns the user performing the call, it's set only for methods that require a role
+func ActorFromContext(ctx context.Context) (dao.UserTable, bool) {
+	actor, ok := ctx.Value(actorKey{}).(dao.UserTable)
+	return actor, ok
+}
+
+// UnaryServerInterceptor checks that the actor is allowed to call the method
+func UnaryServerInterceptor(d dao.DAO) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		roles, err := methodRoles(method)
+		if err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 {
+			return handler(ctx, req)
+		}
+
+		actor, err := resolveActor(ctx, d, req)
+		if err != nil {
+			return nil, err
+		}
+		role := desc.UserRole(desc.UserRole_value[actor.Role])
+		if !slices.Contains(roles, role) {
+			return nil, permissionDenied(fmt.Sprintf("role %s is not allowed to call %s", role, method))
+		}
+		return handler(context.WithValue(ctx, actorKey{}, actor), req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams,
+// the actor of a stream is resolved from the principal or metadata because there is no request yet
+func StreamServerInterceptor(d dao.DAO) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		method := path.Base(info.FullMethod)
+		roles, err := methodRoles(method)
+		if err != nil {
+			return err
+		}
+		if len(roles) == 0 {
+			return handler(srv, ss)
+		}
+
+		ctx := ss.Context()
+		actor, err := resolveActor(ctx, d, nil)
+		if err != nil {
+			return err
+		}
+		role := desc.UserRole(desc.UserRole_value[actor.Role])
+		if !slices.Contains(roles, role) {
+			return permissionDenied(fmt.Sprintf("role %s is not allowed to call %s", role, method))
+		}
+		return handler(srv, stream.WithContext(ss, context.WithValue(ctx, actorKey{}, actor)))
+	}
+}
+
+// methodRoles returns roles allowed to call the method, no roles mean that the method is open
+func methodRoles(method string) ([]desc.UserRole, error) {
+	roles, ok := methodPermissions[method]
+	if !ok {
+		return nil, permissionDenied(fmt.Sprintf("%s is not listed in permissions", method))
+	}
+	return roles, nil
+}
+
+// RequireRole checks that the actor of the call has one of the roles,
+// it's used by handlers of methods that are open but have privileged options
+func RequireRole(ctx context.Context, d dao.DAO, req interface{}, roles ...desc.UserRole) error {
+	actor, err := resolveActor(ctx, d, req)
+	if err != nil {
+		return err
+	}
+	role := desc.UserRole(desc.UserRole_value[actor.Role])
+	if !slices.Contains(roles, role) {
+		return permissionDenied(fmt.Sprintf("role %s is not allowed to do this", role))
+	}
+	return nil
+}
+
+// CheckSelfOrAdmin makes sure that the actor is the user or an ADMIN,
+// it's used by methods that any user may call on own data
+func CheckSelfOrAdmin(ctx context.Context, userID int64) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return permissionDenied("actor is not resolved")
+	}
+	if actor.Id != userID && actor.Role != desc.UserRole_ADMIN.String() {
+		return permissionDenied(fmt.Sprintf("actor %d can't act on behalf of user %d", actor.Id, userID))
+	}
+	return nil
+}
+
+func resolveActor(ctx context.Context, d dao.DAO, req interface{}) (dao.UserTable, error) {
+	actorID, err := actorIDFromRequest(ctx, req)
+	if err != nil {
+		return dao.UserTable{}, err
+	}
+
+	actor, err := d.NewUserQuery().GetUser(ctx, actorID)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return actor, permissionDenied(fmt.Sprintf("actor %d does not exist", actorID))
+	}
+	if err != nil {
+		return actor, errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if actor.Status != desc.UserStatus_ACTIVE.String() {
+		return actor, permissionDenied(fmt.Sprintf("actor %d is not active", actorID))
+	}
+	return actor, nil
+}
+
+// actorIDFromRequest returns the user performing the call.
+// Every call has a principal if authentication is enabled, then the actor is the user of the principal
+// and principals not bound to a user can't call methods that require a role.
+// The actor is taken from the request only if authentication is disabled.
+func actorIDFromRequest(ctx context.Context, req interface{}) (int64, error) {
+	if p, ok := auth.FromContext(ctx); ok {
+		if p.UserID <= 0 {
+			return 0, permissionDenied(fmt.Sprintf("%s is not bound to a user", p.Subject))
+		}
+		if r, ok := req.(senderRequest); ok && r.GetSenderId() > 0 && r.GetSenderId() != p.UserID {
+			return 0, permissionDenied(fmt.Sprintf("%s can send only on behalf of user %d", p.Subject, p.UserID))
+		}
+		return p.UserID, nil
+	}
+
+	if r, ok := req.(senderRequest); ok && r.GetSenderId() > 0 {
+		return r.GetSenderId(), nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, ActorMetadataKey)
+	if len(values) == 0 {
+		return 0, permissionDenied("actor is not specified, pass " + ActorMetadataKey + " metadata")
+	}
+	actorID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || actorID <= 0 {
+		return 0, permissionDenied(ActorMetadataKey + " must be a user id")
+	}
+	return actorID, nil
+}
+
+// CheckGroupScope makes sure that a GROUP_MODERATOR actor moderates all the groups
+// and all the users are members of moderated groups. Other actors are not limited.
+func CheckGroupScope(ctx context.Context, groupQuery dao.GroupQuery, groupIDs []int64, userIDs []int64) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.Role != desc.UserRole_GROUP_MODERATOR.String() {
+		return nil
+	}
+
+	moderatedIDs, err := groupQuery.GetModeratedGroupIDs(ctx, actor.Id)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	for _, groupID := range groupIDs {
+		if !slices.Contains(moderatedIDs, groupID) {
+			return permissionDenied(fmt.Sprintf("group %d is not moderated by actor %d", groupID, actor.Id))
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	members := make(map[int64]struct{})
+	if len(moderatedIDs) > 0 {
+		memberIDs, err := groupQuery.GetMemberIDs(ctx, moderatedIDs)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, memberID := range memberIDs {
+			members[memberID] = struct{}{}
+		}
+	}
+	for _, userID := range userIDs {
+		if _, ok := members[userID]; !ok {
+			return permissionDenied(fmt.Sprintf("user %d is not a member of groups moderated by actor %d", userID, actor.Id))
+		}
+	}
+	return nil
+}
+
+func permissionDenied(reason string) error {
+	return errors.NewNetworkError(codes.PermissionDenied, reason).ToGRPCError()
+}
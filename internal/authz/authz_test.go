@@ -0,0 +1,355 @@
+package authz
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/dao"
+)
+
+// testDAO serves users and api clients from memory, other queries are not used by authentication and authz
+type testDAO struct {
+	dao.DAO
+}
+
+func (testDAO) NewUserQuery() dao.UserQuery {
+	return testUserQuery{}
+}
+
+func (testDAO) NewApiClientQuery() dao.ApiClientQuery {
+	return testApiClientQuery{}
+}
+
+type testUserQuery struct {
+	dao.UserQuery
+}
+
+func (testUserQuery) GetUser(_ context.Context, userID int64) (dao.UserTable, error) {
+	user, ok := testUsers[userID]
+	if !ok {
+		return dao.UserTable{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+type testApiClientQuery struct {
+	dao.ApiClientQuery
+}
+
+func (testApiClientQuery) GetApiClientByKeyHash(_ context.Context, keyHash string) (dao.ApiClientTable, error) {
+	for key, client := range testApiClients {
+		if auth.HashApiKey(key) == keyHash {
+			return client, nil
+		}
+	}
+	return dao.ApiClientTable{}, sql.ErrNoRows
+}
+
+var testUsers = map[int64]dao.UserTable{
+	1: {Id: 1, Role: desc.UserRole_ADMIN.String(), Status: desc.UserStatus_ACTIVE.String()},
+	2: {Id: 2, Role: desc.UserRole_WRITER.String(), Status: desc.UserStatus_ACTIVE.String()},
+	3: {Id: 3, Role: desc.UserRole_READER.String(), Status: desc.UserStatus_ACTIVE.String()},
+	4: {Id: 4, Role: desc.UserRole_ADMIN.String(), Status: desc.UserStatus_DISABLED.String()},
+}
+
+// testApiClients are api clients by their keys
+var testApiClients = map[string]dao.ApiClientTable{
+	"admin-key":   {ID: 1, Name: "admin client", UserID: sql.NullInt64{Int64: 1, Valid: true}},
+	"writer-key":  {ID: 2, Name: "writer client", UserID: sql.NullInt64{Int64: 2, Valid: true}},
+	"service-key": {ID: 3, Name: "service client"},
+}
+
+// testServer succeeds every call that passes the interceptors,
+// SubscribeToTopic checks the actor passed to the handler like the real handler does
+type testServer struct {
+	desc.UnimplementedTelegramNotificationServiceServer
+}
+
+func (testServer) GetUser(context.Context, *desc.GetUserRequest) (*desc.GetUserResponse, error) {
+	return &desc.GetUserResponse{}, nil
+}
+
+func (testServer) DeleteUser(context.Context, *desc.DeleteUserRequest) (*desc.DeleteUserResponse, error) {
+	return &desc.DeleteUserResponse{}, nil
+}
+
+func (testServer) SendNotification(
+	context.Context,
+	*desc.SendNotificationRequest,
+) (*desc.SendNotificationResponse, error) {
+	return &desc.SendNotificationResponse{}, nil
+}
+
+func (testServer) SubscribeToTopic(
+	ctx context.Context,
+	req *desc.SubscribeToTopicRequest,
+) (*desc.SubscribeToTopicResponse, error) {
+	if err := CheckSelfOrAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	return &desc.SubscribeToTopicResponse{}, nil
+}
+
+func (testServer) WatchNotification(
+	*desc.WatchNotificationRequest,
+	desc.TelegramNotificationService_WatchNotificationServer,
+) error {
+	return nil
+}
+
+// dialTestServer serves testServer behind the interceptors of the app,
+// the authenticator is chained before authz only if authenticate is true
+func dialTestServer(t *testing.T, authenticate bool) desc.TelegramNotificationServiceClient {
+	t.Helper()
+
+	var unary []grpc.UnaryServerInterceptor
+	var streams []grpc.StreamServerInterceptor
+	if authenticate {
+		authenticator := auth.NewAuthenticator(testDAO{}, nil)
+		unary = append(unary, authenticator.UnaryServerInterceptor())
+		streams = append(streams, authenticator.StreamServerInterceptor())
+	}
+	unary = append(unary, UnaryServerInterceptor(testDAO{}))
+	streams = append(streams, StreamServerInterceptor(testDAO{}))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(streams...))
+	desc.RegisterTelegramNotificationServiceServer(srv, testServer{})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return desc.NewTelegramNotificationServiceClient(conn)
+}
+
+func deleteUser(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+	_, err := client.DeleteUser(ctx, &desc.DeleteUserRequest{})
+	return err
+}
+
+func subscribe(userID int64) func(context.Context, desc.TelegramNotificationServiceClient) error {
+	return func(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+		_, err := client.SubscribeToTopic(ctx, &desc.SubscribeToTopicRequest{UserId: userID, TopicId: 1})
+		return err
+	}
+}
+
+func send(senderID int64) func(context.Context, desc.TelegramNotificationServiceClient) error {
+	return func(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+		_, err := client.SendNotification(ctx, &desc.SendNotificationRequest{SenderId: senderID})
+		return err
+	}
+}
+
+func watch(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+	stream, err := client.WatchNotification(ctx, &desc.WatchNotificationRequest{NotificationId: 1})
+	if err != nil {
+		return err
+	}
+	if _, err = stream.Recv(); err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func TestInterceptors(t *testing.T) {
+	tests := []struct {
+		name         string
+		authenticate bool
+		// metadata is key-value pairs sent with the call
+		metadata []string
+		call     func(context.Context, desc.TelegramNotificationServiceClient) error
+		want     codes.Code
+	}{
+		{
+			name: "open method without actor",
+			call: func(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+				_, err := client.GetUser(ctx, &desc.GetUserRequest{})
+				return err
+			},
+			want: codes.OK,
+		},
+		{name: "admin method by admin", metadata: []string{ActorMetadataKey, "1"}, call: deleteUser, want: codes.OK},
+		{
+			name:     "admin method by writer",
+			metadata: []string{ActorMetadataKey, "2"},
+			call:     deleteUser,
+			want:     codes.PermissionDenied,
+		},
+		{name: "admin method without actor", call: deleteUser, want: codes.PermissionDenied},
+		{
+			name:     "malformed actor",
+			metadata: []string{ActorMetadataKey, "admin"},
+			call:     deleteUser,
+			want:     codes.PermissionDenied,
+		},
+		{
+			name:     "unknown actor",
+			metadata: []string{ActorMetadataKey, "100"},
+			call:     deleteUser,
+			want:     codes.PermissionDenied,
+		},
+		{
+			name:     "disabled actor",
+			metadata: []string{ActorMetadataKey, "4"},
+			call:     deleteUser,
+			want:     codes.PermissionDenied,
+		},
+		{name: "sender of the request", call: send(2), want: codes.OK},
+		{name: "reader as sender", call: send(3), want: codes.PermissionDenied},
+		{
+			name:     "sender overrides actor metadata",
+			metadata: []string{ActorMetadataKey, "1"},
+			call:     send(3),
+			want:     codes.PermissionDenied,
+		},
+		{name: "subscribe self", metadata: []string{ActorMetadataKey, "3"}, call: subscribe(3), want: codes.OK},
+		{
+			name:     "subscribe another user",
+			metadata: []string{ActorMetadataKey, "3"},
+			call:     subscribe(2),
+			want:     codes.PermissionDenied,
+		},
+		{
+			name:     "admin subscribes another user",
+			metadata: []string{ActorMetadataKey, "1"},
+			call:     subscribe(2),
+			want:     codes.OK,
+		},
+		{name: "stream by writer", metadata: []string{ActorMetadataKey, "2"}, call: watch, want: codes.OK},
+		{name: "stream by reader", metadata: []string{ActorMetadataKey, "3"}, call: watch, want: codes.PermissionDenied},
+		{name: "stream without actor", call: watch, want: codes.PermissionDenied},
+		{
+			name:         "api key bound to admin",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "admin-key"},
+			call:         deleteUser,
+			want:         codes.OK,
+		},
+		{
+			name:         "api key ignores actor metadata",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "writer-key", ActorMetadataKey, "1"},
+			call:         deleteUser,
+			want:         codes.PermissionDenied,
+		},
+		{
+			name:         "api key not bound to user",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "service-key", ActorMetadataKey, "1"},
+			call:         deleteUser,
+			want:         codes.PermissionDenied,
+		},
+		{
+			name:         "api key not bound to user calls open method",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "service-key"},
+			call: func(ctx context.Context, client desc.TelegramNotificationServiceClient) error {
+				_, err := client.GetUser(ctx, &desc.GetUserRequest{})
+				return err
+			},
+			want: codes.OK,
+		},
+		{
+			name:         "api key sends on behalf of another user",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "writer-key"},
+			call:         send(1),
+			want:         codes.PermissionDenied,
+		},
+		{
+			name:         "api key subscribes own user",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "writer-key"},
+			call:         subscribe(2),
+			want:         codes.OK,
+		},
+		{
+			name:         "api key streams",
+			authenticate: true,
+			metadata:     []string{auth.ApiKeyMetadataKey, "writer-key"},
+			call:         watch,
+			want:         codes.OK,
+		},
+		{
+			name:         "actor metadata without credentials",
+			authenticate: true,
+			metadata:     []string{ActorMetadataKey, "1"},
+			call:         deleteUser,
+			want:         codes.Unauthenticated,
+		},
+	}
+	clients := map[bool]desc.TelegramNotificationServiceClient{
+		false: dialTestServer(t, false),
+		true:  dialTestServer(t, true),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(tt.metadata...))
+			err := tt.call(ctx, clients[tt.authenticate])
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %s, want %s (err = %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUnlistedMethodIsDenied(t *testing.T) {
+	interceptor := UnaryServerInterceptor(testDAO{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/notification.v1.telegram_notification_service/DropDatabase"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		t.Fatal("handler of an unlisted method is called")
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), nil, info, handler)
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("code = %s, want %s", got, codes.PermissionDenied)
+	}
+}
+
+func TestMethodPermissionsCoverService(t *testing.T) {
+	serviceMethods := make(map[string]bool)
+	for _, method := range desc.TelegramNotificationService_ServiceDesc.Methods {
+		serviceMethods[method.MethodName] = true
+	}
+	for _, stream := range desc.TelegramNotificationService_ServiceDesc.Streams {
+		serviceMethods[stream.StreamName] = true
+	}
+
+	for method := range serviceMethods {
+		if _, ok := methodPermissions[method]; !ok {
+			t.Errorf("%s is not listed in methodPermissions", method)
+		}
+	}
+	for method := range methodPermissions {
+		if !serviceMethods[method] {
+			t.Errorf("%s is listed in methodPermissions but the service has no such method", method)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package authz
+
+import desc "telegram-notification-api/api"
+
+// open marks methods available to everyone, they don't require an actor.
+// Handlers of open methods with privileged options check the role themselves with RequireRole.
+var open []desc.UserRole
+
+// methodPermissions lists roles allowed to call a method, every method of the service must be listed.
+// Calls of methods that are absent are denied.
+var methodPermissions = map[string][]desc.UserRole{
+	"GetNotification":           open,
+	"GetNotifications":          open,
+	"SendNotification":          {desc.UserRole_WRITER, desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+	"SearchNotifications":       {desc.UserRole_ADMIN},
+	"WatchNotification":         {desc.UserRole_WRITER, desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+	"GetUserInbox":              {desc.UserRole_ADMIN},
+	"GetNotificationStats":      {desc.UserRole_ADMIN},
+	"GetNotificationEngagement": {desc.UserRole_ADMIN},
+
+	"GetUser":             open,
+	"GetUserByTelegramID": open,
+	"GetUsersById":        open,
+	"GetUsersByFilter":    open,
+	"CreateUser":          {desc.UserRole_ADMIN},
+	"ImportUsers":         {desc.UserRole_ADMIN},
+	"ExportUsers":         {desc.UserRole_ADMIN},
+	"BatchCreateUsers":    {desc.UserRole_ADMIN},
+	"BatchEditUsers":      {desc.UserRole_ADMIN},
+	"BatchDeleteUsers":    {desc.UserRole_ADMIN},
+	"GetUserDuplicates":   {desc.UserRole_ADMIN},
+	"EditUser":            {desc.UserRole_ADMIN},
+	"DeleteUser":          {desc.UserRole_ADMIN},
+	"RestoreUser":         {desc.UserRole_ADMIN},
+	"PurgeUser":           {desc.UserRole_ADMIN},
+
+	"ExportUserData": {desc.UserRole_ADMIN},
+	"EraseUserData":  {desc.UserRole_ADMIN},
+
+	"GetGroups":            open,
+	"GetGroup":             open,
+	"GetGroupTree":         open,
+	"CreateGroup":          {desc.UserRole_ADMIN},
+	"EditGroup":            {desc.UserRole_ADMIN},
+	"DeleteGroup":          {desc.UserRole_ADMIN},
+	"RenameGroup":          {desc.UserRole_ADMIN},
+	"MergeGroups":          {desc.UserRole_ADMIN},
+	"AddGroupModerator":    {desc.UserRole_ADMIN},
+	"RemoveGroupModerator": {desc.UserRole_ADMIN},
+	"AddUserToGroup":       {desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+	"RemoveUserFromGroup":  {desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+
+	"GetTopic":    open,
+	"GetTopics":   open,
+	"CreateTopic": {desc.UserRole_ADMIN},
+	"EditTopic":   {desc.UserRole_ADMIN},
+	"DeleteTopic": {desc.UserRole_ADMIN},
+	// every user manages own subscriptions, handlers check that with CheckSelfOrAdmin
+	"SubscribeToTopic":     {desc.UserRole_READER, desc.UserRole_WRITER, desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+	"UnsubscribeFromTopic": {desc.UserRole_READER, desc.UserRole_WRITER, desc.UserRole_ADMIN, desc.UserRole_GROUP_MODERATOR},
+
+	"CreateApiClient": {desc.UserRole_ADMIN},
+	"GetApiClient":    {desc.UserRole_ADMIN},
+	"GetApiClients":   {desc.UserRole_ADMIN},
+	"EditApiClient":   {desc.UserRole_ADMIN},
+	"DeleteApiClient": {desc.UserRole_ADMIN},
+	"GetClientUsage":  open,
+
+	"GetAuditLog": {desc.UserRole_ADMIN},
+
+	"CreateWebhook":        {desc.UserRole_ADMIN},
+	"GetWebhooks":          {desc.UserRole_ADMIN},
+	"EditWebhook":          {desc.UserRole_ADMIN},
+	"DeleteWebhook":        {desc.UserRole_ADMIN},
+	"GetWebhookDeliveries": {desc.UserRole_ADMIN},
+	"RetryWebhookDelivery": {desc.UserRole_ADMIN},
+}
@@ -0,0 +1,168 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"telegram-notification-api/internal/config"
+)
+
+// Reloader keeps the server certificate and the client CA pool loaded from disk
+// and picks up rotated files without a restart
+type Reloader struct {
+	cfg config.TLS
+	log *slog.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// modTimes of the files the current state is loaded from
+	modTimes map[string]time.Time
+}
+
+func NewReloader(cfg config.TLS, log *slog.Logger) (*Reloader, error) {
+	const op = "certs.NewReloader"
+
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("%s: cert_file and key_file are required", op)
+	}
+
+	r := &Reloader{
+		cfg: cfg,
+		log: log,
+	}
+	if err := r.reload(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return r, nil
+}
+
+// ServerConfig returns tls config which always uses the latest loaded files
+func (r *Reloader) ServerConfig() *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// grpc clients require h2 to be negotiated with alpn, the gateway serves http/1.1 too
+		NextProtos: []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+	}
+
+	c := base.Clone()
+	// the client CA pool is set per connection, so a reloaded pool applies to new connections
+	c.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		if r.clientCAs == nil {
+			return nil, nil
+		}
+		clientConfig := base.Clone()
+		clientConfig.ClientCAs = r.clientCAs
+		clientConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.cfg.RequireClientCert {
+			clientConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		return clientConfig, nil
+	}
+	return c
+}
+
+// Run checks the files for changes every ReloadInterval until ctx is done.
+// A failed reload is logged and the previously loaded files stay in use.
+func (r *Reloader) Run(ctx context.Context) {
+	if r.cfg.ReloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.changed()
+		if err != nil {
+			r.log.Error("can't check tls files", slog.Any("err", err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if err = r.reload(); err != nil {
+			r.log.Error("can't reload tls files", slog.Any("err", err))
+			continue
+		}
+		r.log.Info("tls files reloaded")
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) changed() (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Reloader) reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("can't load key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
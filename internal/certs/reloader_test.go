@@ -0,0 +1,177 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"telegram-notification-api/internal/config"
+)
+
+// issue creates a certificate for the common name signed by the parent, a nil parent makes it a CA
+func issue(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (
+	*x509.Certificate,
+	*ecdsa.PrivateKey,
+) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func writePEM(t *testing.T, file string, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeKeyPair(t *testing.T, cfg config.TLS, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, cfg.CertFile, "CERTIFICATE", cert.Raw)
+	writePEM(t, cfg.KeyFile, "EC PRIVATE KEY", keyDER)
+}
+
+// handshake connects to a listener of the reloader config and returns the state of the connection
+func handshake(t *testing.T, r *Reloader, client *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+	l, err := tls.Listen("tcp", "127.0.0.1:0", r.ServerConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", l.Addr().String(), client)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+	// the server verifies the client certificate after the client finishes the handshake
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err = conn.Read(make([]byte, 1)); err != nil && err != io.EOF {
+		return tls.ConnectionState{}, err
+	}
+	return conn.ConnectionState(), nil
+}
+
+func TestReloaderServerConfig(t *testing.T) {
+	ca, caKey := issue(t, "ca", nil, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	client, clientKey := issue(t, "client", ca, caKey)
+	clientCert := tls.Certificate{Certificate: [][]byte{client.Raw}, PrivateKey: clientKey}
+
+	dir := t.TempDir()
+	cfg := config.TLS{
+		CertFile:          filepath.Join(dir, "server.pem"),
+		KeyFile:           filepath.Join(dir, "server.key"),
+		ClientCAFile:      filepath.Join(dir, "ca.pem"),
+		RequireClientCert: true,
+	}
+	server, serverKey := issue(t, "server", ca, caKey)
+	writeKeyPair(t, cfg, server, serverKey)
+	writePEM(t, cfg.ClientCAFile, "CERTIFICATE", ca.Raw)
+
+	r, err := NewReloader(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		// protos are offered by the client with alpn
+		protos    []string
+		cert      *tls.Certificate
+		wantProto string
+		wantErr   bool
+	}{
+		{name: "grpc client", protos: []string{"h2"}, cert: &clientCert, wantProto: "h2"},
+		{name: "http client", protos: []string{"h2", "http/1.1"}, cert: &clientCert, wantProto: "h2"},
+		{name: "http/1.1 client", protos: []string{"http/1.1"}, cert: &clientCert, wantProto: "http/1.1"},
+		{name: "no client certificate", protos: []string{"h2"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConfig := &tls.Config{RootCAs: roots, NextProtos: tt.protos}
+			if tt.cert != nil {
+				clientConfig.Certificates = []tls.Certificate{*tt.cert}
+			}
+			state, err := handshake(t, r, clientConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handshake error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && state.NegotiatedProtocol != tt.wantProto {
+				t.Errorf("negotiated protocol = %q, want %q", state.NegotiatedProtocol, tt.wantProto)
+			}
+		})
+	}
+
+	t.Run("rotated certificate", func(t *testing.T) {
+		rotated, rotatedKey := issue(t, "rotated server", ca, caKey)
+		writeKeyPair(t, cfg, rotated, rotatedKey)
+		// mod times of files written within the same tick may not change, so the reload is forced
+		if err = r.reload(); err != nil {
+			t.Fatalf("reload() error = %v", err)
+		}
+
+		state, err := handshake(t, r, &tls.Config{
+			RootCAs:      roots,
+			NextProtos:   []string{"h2"},
+			Certificates: []tls.Certificate{clientCert},
+		})
+		if err != nil {
+			t.Fatalf("handshake error = %v", err)
+		}
+		if got := state.PeerCertificates[0].Subject.CommonName; got != "rotated server" {
+			t.Errorf("server certificate = %q, want the rotated one", got)
+		}
+	})
+}
@@ -2,10 +2,17 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
 )
 
+// ErrBotBlocked is returned when the receiver has blocked the bot
+var ErrBotBlocked = errors.New("bot is blocked by the user")
+
 type TelegramClient interface {
 	SendMessage(
 		ctx context.Context,
@@ -14,14 +21,29 @@ type TelegramClient interface {
 		mediaContent *string,
 		disableNotification bool,
 	) error
+	// HandleCommand calls handler for messages with the bot command, command is a name without the slash
+	HandleCommand(command string, handler CommandHandler)
+	// Start receives updates of the bot and calls command handlers until ctx is done,
+	// only one instance of the bot may receive updates
+	Start(ctx context.Context)
+}
+
+// Command is a bot command sent by a telegram user
+type Command struct {
+	ChatID     int64
+	TelegramID int64
+	// Args is the text after the command
+	Args string
 }
 
+type CommandHandler func(ctx context.Context, command Command)
+
 type telegramClient struct {
 	b *bot.Bot
 }
 
 func NewTelegramClient(token string) (TelegramClient, error) {
-	b, err := bot.New(token)
+	b, err := bot.New(token, bot.WithDefaultHandler(func(context.Context, *bot.Bot, *models.Update) {}))
 	if err != nil {
 		return nil, err
 	}
@@ -40,5 +62,43 @@ func (c *telegramClient) SendMessage(
 		Text:                message,
 		DisableNotification: disableNotification,
 	})
+	if errors.Is(err, bot.ErrorForbidden) {
+		return fmt.Errorf("%w: %w", ErrBotBlocked, err)
+	}
 	return err
 }
+
+func (c *telegramClient) HandleCommand(command string, handler CommandHandler) {
+	c.b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			_, ok := parseCommand(update.Message, command)
+			return ok
+		},
+		func(ctx context.Context, _ *bot.Bot, update *models.Update) {
+			args, _ := parseCommand(update.Message, command)
+			handler(ctx, Command{
+				ChatID:     update.Message.Chat.ID,
+				TelegramID: update.Message.From.ID,
+				Args:       args,
+			})
+		},
+	)
+}
+
+func (c *telegramClient) Start(ctx context.Context) {
+	c.b.Start(ctx)
+}
+
+// parseCommand returns arguments of the command if the message is the command,
+// the command may be addressed to the bot as /command@bot_name
+func parseCommand(message *models.Message, command string) (string, bool) {
+	if message == nil || message.From == nil {
+		return "", false
+	}
+	name, args, _ := strings.Cut(message.Text, " ")
+	name, _, _ = strings.Cut(name, "@")
+	if name != "/"+command {
+		return "", false
+	}
+	return strings.TrimSpace(args), true
+}
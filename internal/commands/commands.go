@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+
+	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+)
+
+// telegramMessageLimit is the maximum length of a telegram message in characters
+const telegramMessageLimit = 4096
+
+// Register adds handlers of bot commands to the client, they are called once the client is started
+func Register(log *slog.Logger, client clients.TelegramClient, d dao.DAO, cfg config.Bot) {
+	history := &historyCommand{
+		log:     log,
+		client:  client,
+		dao:     d,
+		size:    cfg.HistorySize,
+		maxSize: cfg.MaxHistorySize,
+	}
+	client.HandleCommand("history", history.handle)
+}
+
+// reply sends the text to the chat of the command, the text is split into several messages if it's too long
+func reply(ctx context.Context, log *slog.Logger, client clients.TelegramClient, command clients.Command, text string) {
+	for _, message := range splitMessage(text) {
+		if err := client.SendMessage(ctx, command.ChatID, message, nil, false); err != nil {
+			log.Error("can't reply to bot command", slog.Int64("chat_id", command.ChatID), slog.Any("err", err))
+			return
+		}
+	}
+}
+
+// splitMessage cuts text into messages of telegramMessageLimit characters at line breaks where possible
+func splitMessage(text string) []string {
+	var messages []string
+	runes := []rune(text)
+	for len(runes) > telegramMessageLimit {
+		end := telegramMessageLimit
+		for idx := end - 1; idx > 0; idx-- {
+			if runes[idx] == '\n' {
+				end = idx
+				break
+			}
+		}
+		messages = append(messages, string(runes[:end]))
+		runes = runes[end:]
+		for len(runes) > 0 && runes[0] == '\n' {
+			runes = runes[1:]
+		}
+	}
+	if len(runes) > 0 {
+		messages = append(messages, string(runes))
+	}
+	return messages
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/dao"
+)
+
+const (
+	// historyPreviewLength is the number of characters of a message shown by /history
+	historyPreviewLength = 200
+	historyDateLayout    = "2006-01-02 15:04"
+)
+
+// historyCommand answers /history [count] with the last notifications received by the user
+type historyCommand struct {
+	log     *slog.Logger
+	client  clients.TelegramClient
+	dao     dao.DAO
+	size    int
+	maxSize int
+}
+
+func (c *historyCommand) handle(ctx context.Context, command clients.Command) {
+	size := c.size
+	if command.Args != "" {
+		n, err := strconv.Atoi(command.Args)
+		if err != nil || n <= 0 || n > c.maxSize {
+			reply(ctx, c.log, c.client, command, fmt.Sprintf("Usage: /history [count], count is from 1 to %d", c.maxSize))
+			return
+		}
+		size = n
+	}
+
+	users, err := c.dao.NewUserQuery().GetUsersByTelegramIds(ctx, []int64{command.TelegramID})
+	if err != nil {
+		c.log.Error("can't find user of bot command", slog.Int64("telegram_id", command.TelegramID), slog.Any("err", err))
+		return
+	}
+	if len(users) == 0 {
+		reply(ctx, c.log, c.client, command, "You are not registered to receive notifications.")
+		return
+	}
+
+	items, err := c.dao.NewDeliveryQuery().GetInbox(ctx, users[0].Id, uint64(size), 0)
+	if err != nil {
+		c.log.Error("can't get inbox of bot command", slog.Int64("user_id", users[0].Id), slog.Any("err", err))
+		return
+	}
+	if len(items) == 0 {
+		reply(ctx, c.log, c.client, command, "You have no notifications yet.")
+		return
+	}
+	reply(ctx, c.log, c.client, command, formatHistory(items))
+}
+
+func formatHistory(items []dao.InboxTable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your last %d notifications:\n", len(items))
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n#%d · %s · %s\n", item.ID, item.Date.Format(historyDateLayout), item.DeliveryStatus)
+		b.WriteString(preview(item.Message))
+		if item.MediaContent.Valid {
+			b.WriteString("\n[media]")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// preview cuts the message to historyPreviewLength characters
+func preview(message string) string {
+	runes := []rune(message)
+	if len(runes) <= historyPreviewLength {
+		return message
+	}
+	return string(runes[:historyPreviewLength]) + "…"
+}
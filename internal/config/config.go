@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -18,6 +20,131 @@ type Config interface {
 
 	GetTelegramBotToken() (string, error)
 	MustGetTelegramBotToken() string
+
+	GetAuthEnabled() (bool, error)
+	MustGetAuthEnabled() bool
+
+	GetAuthJWTIssuer() (string, error)
+	MustGetAuthJWTIssuer() string
+
+	GetAuthJWTAudience() (string, error)
+	MustGetAuthJWTAudience() string
+
+	GetAuthJWKSFile() (string, error)
+	MustGetAuthJWKSFile() string
+
+	GetRateLimits() (RateLimits, error)
+	MustGetRateLimits() RateLimits
+
+	GetTLS() (TLS, error)
+	MustGetTLS() TLS
+
+	GetPagination() (Pagination, error)
+	MustGetPagination() Pagination
+
+	GetBot() (Bot, error)
+	MustGetBot() Bot
+
+	GetStats() (Stats, error)
+	MustGetStats() Stats
+
+	GetLinks() (Links, error)
+	MustGetLinks() Links
+	GetWebhooks() (Webhooks, error)
+	MustGetWebhooks() Webhooks
+	GetGateway() (Gateway, error)
+	MustGetGateway() Gateway
+	GetImport() (Import, error)
+	MustGetImport() Import
+}
+
+type RateLimit struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+type RateLimits struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Default applies to every method of every client
+	Default RateLimit `mapstructure:"default"`
+	// Methods overrides Default, keys are method names in lower case
+	Methods map[string]RateLimit `mapstructure:"methods"`
+	// DailyMessageQuota is the number of messages a client can send per UTC day, 0 means unlimited
+	DailyMessageQuota int64 `mapstructure:"daily_message_quota"`
+}
+
+type TLS struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// ClientCAFile is a pem bundle to verify client certificates against, empty disables mutual tls
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// RequireClientCert rejects connections without a client certificate,
+	// otherwise the certificate is verified only if the client sends it
+	RequireClientCert bool `mapstructure:"require_client_cert"`
+	// ReloadInterval is how often files are checked for rotation, 0 disables reload
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+type Pagination struct {
+	// MaxPageSize caps limit of list requests
+	MaxPageSize uint64 `mapstructure:"max_page_size"`
+	// TokenSecret signs page tokens, it must be the same on all instances
+	TokenSecret string `mapstructure:"token_secret"`
+}
+
+type Bot struct {
+	// CommandsEnabled makes the instance receive updates of the bot and answer commands,
+	// it must be enabled on one instance only
+	CommandsEnabled bool `mapstructure:"commands_enabled"`
+	// HistorySize is the number of notifications /history shows without an argument
+	HistorySize int `mapstructure:"history_size"`
+	// MaxHistorySize caps the argument of /history
+	MaxHistorySize int `mapstructure:"max_history_size"`
+}
+
+type Stats struct {
+	// RollupRefreshInterval is how often the hourly rollup of delivery stats is refreshed,
+	// 0 disables refresh on the instance
+	RollupRefreshInterval time.Duration `mapstructure:"rollup_refresh_interval"`
+}
+
+type Links struct {
+	// Enabled allows tracking links of notifications and serves redirect links
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"`
+	Port    int    `mapstructure:"port"`
+	// BaseURL is the public address redirect links are built with, like https://t.example.com
+	BaseURL string `mapstructure:"base_url"`
+	// Secret signs codes of redirect links, it must be the same on all instances
+	Secret string `mapstructure:"secret"`
+}
+
+type Webhooks struct {
+	// DispatchInterval is how often due webhook deliveries are sent, 0 disables dispatch on the instance
+	DispatchInterval time.Duration `mapstructure:"dispatch_interval"`
+	// BatchSize is the max number of deliveries sent per dispatch
+	BatchSize uint64 `mapstructure:"batch_size"`
+	// MaxAttempts is the number of attempts after which a delivery is dead
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// InitialBackoff is the delay before the first retry, it doubles with every attempt up to MaxBackoff
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// Timeout limits a single request to a webhook, it's required because it bounds the lease of deliveries
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type Gateway struct {
+	// Enabled serves the http/json api translated to grpc calls on server_host.
+	// Callers authenticate the same way as on the grpc port, it uses tls settings of the grpc server.
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+}
+
+type Import struct {
+	// MaxUploadSize caps an ImportUsers upload in bytes, the upload is kept in memory until it's parsed.
+	// 0 means 10 MiB.
+	MaxUploadSize int64 `mapstructure:"max_upload_size"`
 }
 
 type config struct {
@@ -33,6 +160,19 @@ const (
 	ServerPortValue          configValue = "server_port"
 	DatabaseConnectionString configValue = "database_connection_string"
 	TelegramBotTokenString   configValue = "telegram_bot_token"
+	AuthEnabledValue         configValue = "auth_enabled"
+	AuthJWTIssuerValue       configValue = "auth_jwt_issuer"
+	AuthJWTAudienceValue     configValue = "auth_jwt_audience"
+	AuthJWKSFileValue        configValue = "auth_jwks_file"
+	RateLimitsValue          configValue = "rate_limits"
+	TLSValue                 configValue = "tls"
+	PaginationValue          configValue = "pagination"
+	BotValue                 configValue = "bot"
+	StatsValue               configValue = "stats"
+	LinksValue               configValue = "links"
+	WebhooksValue            configValue = "webhooks"
+	GatewayValue             configValue = "gateway"
+	ImportValue              configValue = "import"
 )
 
 type envValue int
@@ -117,6 +257,254 @@ func (c *config) MustGetTelegramBotToken() string {
 	return v.(string)
 }
 
+func (c *config) GetAuthEnabled() (bool, error) {
+	const op = "config.GetAuthEnabled"
+	v, err := c.getValueFromConfig(AuthEnabledValue)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return v.(bool), err
+}
+
+func (c *config) MustGetAuthEnabled() bool {
+	v, err := c.getValueFromConfig(AuthEnabledValue)
+	if err != nil {
+		panic(err)
+	}
+	return v.(bool)
+}
+
+func (c *config) GetAuthJWTIssuer() (string, error) {
+	const op = "config.GetAuthJWTIssuer"
+	v, err := c.getValueFromConfig(AuthJWTIssuerValue)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return v.(string), err
+}
+
+func (c *config) MustGetAuthJWTIssuer() string {
+	v, err := c.getValueFromConfig(AuthJWTIssuerValue)
+	if err != nil {
+		panic(err)
+	}
+	return v.(string)
+}
+
+func (c *config) GetAuthJWTAudience() (string, error) {
+	const op = "config.GetAuthJWTAudience"
+	v, err := c.getValueFromConfig(AuthJWTAudienceValue)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return v.(string), err
+}
+
+func (c *config) MustGetAuthJWTAudience() string {
+	v, err := c.getValueFromConfig(AuthJWTAudienceValue)
+	if err != nil {
+		panic(err)
+	}
+	return v.(string)
+}
+
+func (c *config) GetAuthJWKSFile() (string, error) {
+	const op = "config.GetAuthJWKSFile"
+	v, err := c.getValueFromConfig(AuthJWKSFileValue)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return v.(string), err
+}
+
+func (c *config) MustGetAuthJWKSFile() string {
+	v, err := c.getValueFromConfig(AuthJWKSFileValue)
+	if err != nil {
+		panic(err)
+	}
+	return v.(string)
+}
+
+func (c *config) GetRateLimits() (RateLimits, error) {
+	const op = "config.GetRateLimits"
+	var v RateLimits
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(RateLimitsValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetRateLimits() RateLimits {
+	v, err := c.GetRateLimits()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetTLS() (TLS, error) {
+	const op = "config.GetTLS"
+	var v TLS
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(TLSValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetTLS() TLS {
+	v, err := c.GetTLS()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetPagination() (Pagination, error) {
+	const op = "config.GetPagination"
+	var v Pagination
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(PaginationValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetPagination() Pagination {
+	v, err := c.GetPagination()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetBot() (Bot, error) {
+	const op = "config.GetBot"
+	var v Bot
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(BotValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetBot() Bot {
+	v, err := c.GetBot()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetStats() (Stats, error) {
+	const op = "config.GetStats"
+	var v Stats
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(StatsValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetStats() Stats {
+	v, err := c.GetStats()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetLinks() (Links, error) {
+	const op = "config.GetLinks"
+	var v Links
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(LinksValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetLinks() Links {
+	v, err := c.GetLinks()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetWebhooks() (Webhooks, error) {
+	const op = "config.GetWebhooks"
+	var v Webhooks
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(WebhooksValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetWebhooks() Webhooks {
+	v, err := c.GetWebhooks()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetGateway() (Gateway, error) {
+	const op = "config.GetGateway"
+	var v Gateway
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(GatewayValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetGateway() Gateway {
+	v, err := c.GetGateway()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *config) GetImport() (Import, error) {
+	const op = "config.GetImport"
+	var v Import
+	if c == nil {
+		return v, fmt.Errorf("%s: %w", op, errors.New("struct is nil"))
+	}
+	if err := c.v.UnmarshalKey(string(ImportValue), &v); err != nil {
+		return v, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
+
+func (c *config) MustGetImport() Import {
+	v, err := c.GetImport()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func (c *config) getValueFromConfig(val configValue) (any, error) {
 	if c == nil {
 		return "", errors.New("struct is nil")
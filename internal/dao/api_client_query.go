@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"telegram-notification-api/internal/storage"
+)
+
+type ApiClientQuery interface {
+	GetApiClient(ctx context.Context, apiClientID int64) (ApiClientTable, error)
+	GetApiClientByKeyHash(ctx context.Context, keyHash string) (ApiClientTable, error)
+	GetApiClients(ctx context.Context, orderBy []OrderBy, limit uint64, offset uint64) ([]ApiClientTable, error)
+	// GetApiClientsAfter returns api clients after the position of the keyset in its order
+	GetApiClientsAfter(ctx context.Context, keyset Keyset, limit uint64) ([]ApiClientTable, error)
+	CountApiClients(ctx context.Context) (int64, error)
+	GetApiClientsByUser(ctx context.Context, userID int64) ([]ApiClientTable, error)
+	CreateApiClient(ctx context.Context, name string, keyHash string, userID sql.NullInt64) (ApiClientTable, error)
+	ChangeApiClient(ctx context.Context, apiClient ApiClientTable, fields ...string) (ApiClientTable, error)
+	DeleteApiClient(ctx context.Context, apiClientID int64) error
+}
+
+type apiClientQuery struct {
+	storage storage.Storage
+}
+
+func newApiClientQuery(storage storage.Storage) ApiClientQuery {
+	return &apiClientQuery{storage: storage}
+}
+
+func (a *apiClientQuery) GetApiClient(ctx context.Context, apiClientID int64) (ApiClientTable, error) {
+	var dest ApiClientTable
+	query := qb().
+		Select(dest.columns()...).
+		From(apiClientTableName).
+		Where(sq.Eq{"id": apiClientID})
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) GetApiClientByKeyHash(ctx context.Context, keyHash string) (ApiClientTable, error) {
+	var dest ApiClientTable
+	query := qb().
+		Select(dest.columns()...).
+		From(apiClientTableName).
+		Where(sq.Eq{"key_hash": keyHash})
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) GetApiClients(
+	ctx context.Context,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]ApiClientTable, error) {
+	var dest []ApiClientTable
+	query := qb().
+		Select(ApiClientTable{}.columns()...).
+		From(apiClientTableName)
+	query = applyOrderBy(query, orderBy, "id").
+		Limit(limit).
+		Offset(offset)
+
+	err := a.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) GetApiClientsAfter(
+	ctx context.Context,
+	keyset Keyset,
+	limit uint64,
+) ([]ApiClientTable, error) {
+	var dest []ApiClientTable
+	query := qb().
+		Select(ApiClientTable{}.columns()...).
+		From(apiClientTableName)
+	query = applyKeyset(query, keyset, apiClientTableName).
+		Limit(limit)
+
+	err := a.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) CountApiClients(ctx context.Context) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("count(*)").
+		From(apiClientTableName)
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) GetApiClientsByUser(ctx context.Context, userID int64) ([]ApiClientTable, error) {
+	var dest []ApiClientTable
+	query := qb().
+		Select(ApiClientTable{}.columns()...).
+		From(apiClientTableName).
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("id")
+
+	err := a.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) CreateApiClient(
+	ctx context.Context,
+	name string,
+	keyHash string,
+	userID sql.NullInt64,
+) (ApiClientTable, error) {
+	var dest ApiClientTable
+	query := qb().
+		Insert(apiClientTableName).
+		Columns(
+			"name",
+			"key_hash",
+			"user_id",
+		).
+		Values(
+			name,
+			keyHash,
+			userID,
+		).
+		Suffix("RETURNING *")
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) ChangeApiClient(ctx context.Context, apiClient ApiClientTable, fields ...string) (ApiClientTable, error) {
+	var dest ApiClientTable
+	apiClientMap := apiClient.toMap()
+
+	query := qb().
+		Update(apiClientTableName).
+		Where(sq.Eq{"id": apiClient.ID})
+
+	for _, field := range fields {
+		query = query.Set(field, apiClientMap[field])
+	}
+	query = query.Suffix("RETURNING *")
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *apiClientQuery) DeleteApiClient(ctx context.Context, apiClientID int64) error {
+	query := qb().
+		Delete(apiClientTableName).
+		Where(sq.Eq{"id": apiClientID})
+	return a.storage.ExecX(ctx, query)
+}
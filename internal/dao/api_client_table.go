@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"database/sql"
+	"github.com/elgris/stom"
+	"time"
+)
+
+const (
+	apiClientTableName = "api_clients"
+)
+
+type ApiClientTable struct {
+	ID        int64         `db:"id"`
+	Name      string        `db:"name"`
+	KeyHash   string        `db:"key_hash"`
+	UserID    sql.NullInt64 `db:"user_id"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+var apiClientTableStom = stom.MustNewStom(ApiClientTable{})
+
+func (a ApiClientTable) columns() []string {
+	return apiClientTableStom.TagValues()
+}
+
+// Keyset returns the position of the api client in a list ordered by orderBy
+func (a ApiClientTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(a.toMap(), orderBy)
+}
+
+func (a ApiClientTable) toMap() map[string]interface{} {
+	m, err := apiClientTableStom.ToMap(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AuditLogFilter describes conditions for GetAuditRecords, zero values don't limit the result
+type AuditLogFilter struct {
+	ActorID    sql.NullInt64
+	Method     string
+	TargetType string
+	// TargetID matches records with the id among target ids
+	TargetID sql.NullInt64
+	From     sql.NullTime
+	To       sql.NullTime
+}
+
+func (f AuditLogFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	if f.ActorID.Valid {
+		query = query.Where(sq.Eq{"actor_id": f.ActorID.Int64})
+	}
+	if f.Method != "" {
+		query = query.Where(sq.Eq{"method": f.Method})
+	}
+	if f.TargetType != "" {
+		query = query.Where(sq.Eq{"target_type": f.TargetType})
+	}
+	if f.TargetID.Valid {
+		query = query.Where("target_ids @> ARRAY[?::bigint]", f.TargetID.Int64)
+	}
+	if f.From.Valid {
+		query = query.Where(sq.GtOrEq{"created_at": f.From.Time})
+	}
+	if f.To.Valid {
+		query = query.Where(sq.Lt{"created_at": f.To.Time})
+	}
+	return query
+}
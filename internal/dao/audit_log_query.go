@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"telegram-notification-api/internal/storage"
+)
+
+type AuditLogQuery interface {
+	CreateAuditRecord(ctx context.Context, record AuditLogTable) error
+	// GetAuditRecords returns records from newest to oldest when orderBy is empty, limit 0 means no limit
+	GetAuditRecords(
+		ctx context.Context,
+		filter AuditLogFilter,
+		orderBy []OrderBy,
+		limit uint64,
+		offset uint64,
+	) ([]AuditLogTable, error)
+	// GetAuditRecordsAfter returns records after the position of the keyset in its order
+	GetAuditRecordsAfter(
+		ctx context.Context,
+		filter AuditLogFilter,
+		keyset Keyset,
+		limit uint64,
+	) ([]AuditLogTable, error)
+	CountAuditRecords(ctx context.Context, filter AuditLogFilter) (int64, error)
+	// RedactChanges hides values of the fields in records of the targets
+	RedactChanges(ctx context.Context, targetType string, targetIDs []int64, fields []string) error
+}
+
+type auditLogQuery struct {
+	storage storage.Storage
+}
+
+func newAuditLogQuery(storage storage.Storage) AuditLogQuery {
+	return &auditLogQuery{storage: storage}
+}
+
+func (a *auditLogQuery) CreateAuditRecord(ctx context.Context, record AuditLogTable) error {
+	query := qb().
+		Insert(auditLogTableName).
+		Columns(
+			"actor_id",
+			"api_client_id",
+			"method",
+			"target_type",
+			"target_ids",
+			"changes",
+			"request_id",
+		).
+		Values(
+			record.ActorID,
+			record.ApiClientID,
+			record.Method,
+			record.TargetType,
+			record.TargetIDs,
+			record.Changes,
+			record.RequestID,
+		)
+	return a.storage.ExecX(ctx, query)
+}
+
+func (a *auditLogQuery) GetAuditRecords(
+	ctx context.Context,
+	filter AuditLogFilter,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]AuditLogTable, error) {
+	var dest []AuditLogTable
+	if len(orderBy) == 0 {
+		orderBy = []OrderBy{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	}
+	query := qb().
+		Select(AuditLogTable{}.columns()...).
+		From(auditLogTableName)
+	query = applyOrderBy(query, orderBy, "id").
+		Offset(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	query = filter.apply(query)
+
+	err := a.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *auditLogQuery) GetAuditRecordsAfter(
+	ctx context.Context,
+	filter AuditLogFilter,
+	keyset Keyset,
+	limit uint64,
+) ([]AuditLogTable, error) {
+	var dest []AuditLogTable
+	query := filter.apply(
+		qb().
+			Select(AuditLogTable{}.columns()...).
+			From(auditLogTableName),
+	)
+	query = applyKeyset(query, keyset, auditLogTableName).
+		Limit(limit)
+
+	err := a.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *auditLogQuery) CountAuditRecords(ctx context.Context, filter AuditLogFilter) (int64, error) {
+	var dest int64
+	query := filter.apply(
+		qb().
+			Select("count(*)").
+			From(auditLogTableName),
+	)
+
+	err := a.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (a *auditLogQuery) RedactChanges(ctx context.Context, targetType string, targetIDs []int64, fields []string) error {
+	query := qb().
+		Update(auditLogTableName).
+		Set("changes", sq.Expr(
+			"changes || (SELECT COALESCE(jsonb_object_agg(key, ?::jsonb), '{}'::jsonb) "+
+				"FROM jsonb_object_keys(changes) key WHERE key = ANY(?))",
+			`{"before": "`+RedactedValue+`", "after": "`+RedactedValue+`"}`,
+			pq.Array(fields),
+		)).
+		Where(sq.Eq{"target_type": targetType}).
+		Where("target_ids && ?", pq.Array(targetIDs))
+	return a.storage.ExecX(ctx, query)
+}
@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"database/sql"
+	"github.com/elgris/stom"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	auditLogTableName = "audit_log"
+
+	// RedactedValue replaces hidden values of changes
+	RedactedValue = "[redacted]"
+)
+
+type AuditLogTable struct {
+	ID          int64         `db:"id"`
+	ActorID     sql.NullInt64 `db:"actor_id"`
+	ApiClientID sql.NullInt64 `db:"api_client_id"`
+	Method      string        `db:"method"`
+	TargetType  string        `db:"target_type"`
+	TargetIDs   pq.Int64Array `db:"target_ids"`
+	// Changes is a json object of changed fields, {"field": {"before": ..., "after": ...}}
+	Changes   types.JSONText `db:"changes"`
+	RequestID string         `db:"request_id"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+var auditLogTableStom = stom.MustNewStom(AuditLogTable{})
+
+func (a AuditLogTable) columns() []string {
+	return auditLogTableStom.TagValues()
+}
+
+// Keyset returns the position of the record in a list ordered by orderBy
+func (a AuditLogTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(a.toMap(), orderBy)
+}
+
+func (a AuditLogTable) toMap() map[string]interface{} {
+	m, err := auditLogTableStom.ToMap(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,98 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"telegram-notification-api/internal/storage"
+	"time"
+)
+
+const (
+	clientDailyUsageTableName = "client_daily_usage"
+)
+
+type ClientUsageQuery interface {
+	// AddMessages increases the number of messages sent by the client on the day, negative messages decrease it
+	AddMessages(ctx context.Context, client string, day time.Time, messages int64) error
+	// ReserveMessages adds messages to the usage of the client on the day only if the total stays within quota,
+	// false is returned if it doesn't
+	ReserveMessages(ctx context.Context, client string, day time.Time, messages int64, quota int64) (bool, error)
+	GetMessages(ctx context.Context, client string, day time.Time) (int64, error)
+}
+
+type clientUsageQuery struct {
+	storage storage.Storage
+}
+
+func newClientUsageQuery(storage storage.Storage) ClientUsageQuery {
+	return &clientUsageQuery{storage: storage}
+}
+
+func (c *clientUsageQuery) AddMessages(ctx context.Context, client string, day time.Time, messages int64) error {
+	query := qb().
+		Insert(clientDailyUsageTableName).
+		Columns(
+			"client",
+			"day",
+			"messages",
+		).
+		Values(
+			client,
+			day,
+			messages,
+		).
+		Suffix("ON CONFLICT (client, day) DO UPDATE SET messages = " +
+			clientDailyUsageTableName + ".messages + EXCLUDED.messages")
+	return c.storage.ExecX(ctx, query)
+}
+
+func (c *clientUsageQuery) ReserveMessages(
+	ctx context.Context,
+	client string,
+	day time.Time,
+	messages int64,
+	quota int64,
+) (bool, error) {
+	if messages > quota {
+		return false, nil
+	}
+
+	var dest int64
+	query := qb().
+		Insert(clientDailyUsageTableName).
+		Columns(
+			"client",
+			"day",
+			"messages",
+		).
+		Values(
+			client,
+			day,
+			messages,
+		).
+		Suffix("ON CONFLICT (client, day) DO UPDATE SET messages = "+
+			clientDailyUsageTableName+".messages + EXCLUDED.messages WHERE "+
+			clientDailyUsageTableName+".messages + EXCLUDED.messages <= ? RETURNING messages", quota)
+
+	err := c.storage.GetX(ctx, &dest, query)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *clientUsageQuery) GetMessages(ctx context.Context, client string, day time.Time) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("COALESCE(SUM(messages), 0)").
+		From(clientDailyUsageTableName).
+		Where(sq.Eq{
+			"client": client,
+			"day":    day,
+		})
+
+	err := c.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
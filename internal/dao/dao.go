@@ -1,6 +1,8 @@
 package dao
 
 import (
+	"context"
+
 	sq "github.com/Masterminds/squirrel"
 	"telegram-notification-api/internal/storage"
 )
@@ -8,7 +10,18 @@ import (
 type DAO interface {
 	NewNotificationQuery() NotificationQuery
 	NewUserQuery() UserQuery
+	NewTopicQuery() TopicQuery
+	NewGroupQuery() GroupQuery
+	NewApiClientQuery() ApiClientQuery
+	NewClientUsageQuery() ClientUsageQuery
+	NewAuditLogQuery() AuditLogQuery
+	NewDeliveryQuery() DeliveryQuery
+	NewLinkQuery() LinkQuery
+	NewEventQuery() EventQuery
+	NewWebhookQuery() WebhookQuery
 
+	// WithTx runs f inside a transaction, queries created from tx are bound to it
+	WithTx(ctx context.Context, f func(tx DAO) error) error
 	Close() error
 }
 
@@ -24,6 +37,48 @@ func (d *dao) NewUserQuery() UserQuery {
 	return newUserQuery(d.db)
 }
 
+func (d *dao) NewTopicQuery() TopicQuery {
+	return newTopicQuery(d.db)
+}
+
+func (d *dao) NewGroupQuery() GroupQuery {
+	return newGroupQuery(d.db)
+}
+
+func (d *dao) NewApiClientQuery() ApiClientQuery {
+	return newApiClientQuery(d.db)
+}
+
+func (d *dao) NewClientUsageQuery() ClientUsageQuery {
+	return newClientUsageQuery(d.db)
+}
+
+func (d *dao) NewAuditLogQuery() AuditLogQuery {
+	return newAuditLogQuery(d.db)
+}
+
+func (d *dao) NewDeliveryQuery() DeliveryQuery {
+	return newDeliveryQuery(d.db)
+}
+
+func (d *dao) NewLinkQuery() LinkQuery {
+	return newLinkQuery(d.db)
+}
+
+func (d *dao) NewEventQuery() EventQuery {
+	return newEventQuery(d.db)
+}
+
+func (d *dao) NewWebhookQuery() WebhookQuery {
+	return newWebhookQuery(d.db)
+}
+
+func (d *dao) WithTx(ctx context.Context, f func(tx DAO) error) error {
+	return d.db.WithTx(ctx, func(tx storage.Storage) error {
+		return f(NewDAO(tx))
+	})
+}
+
 func (d *dao) Close() error {
 	if d.db == nil {
 		return nil
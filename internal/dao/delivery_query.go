@@ -0,0 +1,239 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/storage"
+	"time"
+)
+
+type DeliveryQuery interface {
+	// CreateDeliveries adds pending deliveries of the notification to the users
+	CreateDeliveries(ctx context.Context, notificationID int64, userIDs []int64, createdAt time.Time) error
+	UpdateDeliveryStatus(
+		ctx context.Context,
+		notificationID int64,
+		userID int64,
+		status string,
+		reason sql.NullString,
+		updatedAt time.Time,
+	) error
+	// GetInbox returns notifications received by the user from newest to oldest
+	GetInbox(ctx context.Context, userID int64, limit uint64, offset uint64) ([]InboxTable, error)
+	// GetInboxBefore is GetInbox for notifications with ids less than beforeID,
+	// beforeID 0 starts from the newest notification
+	GetInboxBefore(ctx context.Context, userID int64, beforeID int64, limit uint64) ([]InboxTable, error)
+	CountInbox(ctx context.Context, userID int64) (int64, error)
+	// GetNotificationDeliveryStats counts deliveries of the notification by status
+	GetNotificationDeliveryStats(ctx context.Context, notificationID int64) ([]DeliveryStatsTable, error)
+	// GetDeliveryStats counts deliveries by status for each key of groupBy
+	GetDeliveryStats(ctx context.Context, filter DeliveryStatsFilter, groupBy StatsGroupBy) ([]DeliveryStatsTable, error)
+	// RefreshDeliveryStats recomputes the hourly rollup of delivery stats
+	RefreshDeliveryStats(ctx context.Context) error
+}
+
+type deliveryQuery struct {
+	db storage.Storage
+}
+
+func newDeliveryQuery(db storage.Storage) DeliveryQuery {
+	return &deliveryQuery{
+		db: db,
+	}
+}
+
+func (d *deliveryQuery) CreateDeliveries(
+	ctx context.Context,
+	notificationID int64,
+	userIDs []int64,
+	createdAt time.Time,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	query := qb().
+		Insert(deliveryTableName).
+		Columns(
+			"notification_id",
+			"user_id",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	for _, userID := range userIDs {
+		query = query.Values(
+			notificationID,
+			userID,
+			desc.DeliveryStatus_PENDING.String(),
+			createdAt,
+			createdAt,
+		)
+	}
+	return d.db.ExecX(ctx, query)
+}
+
+func (d *deliveryQuery) UpdateDeliveryStatus(
+	ctx context.Context,
+	notificationID int64,
+	userID int64,
+	status string,
+	reason sql.NullString,
+	updatedAt time.Time,
+) error {
+	query := qb().
+		Update(deliveryTableName).
+		Set("status", status).
+		Set("error", reason).
+		Set("updated_at", updatedAt).
+		Where(sq.Eq{
+			"notification_id": notificationID,
+			"user_id":         userID,
+		})
+	return d.db.ExecX(ctx, query)
+}
+
+func (d *deliveryQuery) GetInbox(ctx context.Context, userID int64, limit uint64, offset uint64) ([]InboxTable, error) {
+	var dest []InboxTable
+	query := inboxQuery(userID).
+		OrderBy("d.notification_id DESC").
+		Limit(limit).
+		Offset(offset)
+
+	err := d.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (d *deliveryQuery) GetInboxBefore(
+	ctx context.Context,
+	userID int64,
+	beforeID int64,
+	limit uint64,
+) ([]InboxTable, error) {
+	var dest []InboxTable
+	query := inboxQuery(userID)
+	if beforeID > 0 {
+		query = query.Where(sq.Lt{"d.notification_id": beforeID})
+	}
+	query = query.
+		OrderBy("d.notification_id DESC").
+		Limit(limit)
+
+	err := d.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (d *deliveryQuery) CountInbox(ctx context.Context, userID int64) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("count(*)").
+		From(deliveryTableName).
+		Where(sq.Eq{"user_id": userID})
+
+	err := d.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func inboxQuery(userID int64) sq.SelectBuilder {
+	return qb().
+		Select(InboxTable{}.columns()...).
+		From(deliveryTableName + " d").
+		Join(notificationTableName + " n ON n.id = d.notification_id").
+		Where(sq.Eq{"d.user_id": userID})
+}
+
+func (d *deliveryQuery) GetNotificationDeliveryStats(
+	ctx context.Context,
+	notificationID int64,
+) ([]DeliveryStatsTable, error) {
+	var dest []DeliveryStatsTable
+	query := qb().
+		Select(
+			"status",
+			"count(*) AS deliveries",
+			"COALESCE(sum(extract(epoch FROM updated_at - created_at)) "+
+				"FILTER (WHERE status = 'DELIVERED'), 0)::double precision AS latency_sum",
+		).
+		From(deliveryTableName).
+		Where(sq.Eq{"notification_id": notificationID}).
+		GroupBy("status").
+		OrderBy("status")
+
+	err := d.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (d *deliveryQuery) GetDeliveryStats(
+	ctx context.Context,
+	filter DeliveryStatsFilter,
+	groupBy StatsGroupBy,
+) ([]DeliveryStatsTable, error) {
+	var dest []DeliveryStatsTable
+	dimension := StatsBySender
+	keyID, period := "NULL::bigint", "NULL::timestamp"
+	switch groupBy {
+	case StatsBySender:
+		keyID = "key_id"
+	case StatsByGroup:
+		dimension = StatsByGroup
+		keyID = "key_id"
+	case StatsByHour:
+		period = "hour"
+	case StatsByDay:
+		period = "date_trunc('day', hour)"
+	}
+
+	query := qb().
+		Select(
+			keyID+" AS key_id",
+			period+" AS period",
+			"status",
+			"sum(deliveries)::bigint AS deliveries",
+			"sum(latency_sum)::double precision AS latency_sum",
+		).
+		FromSelect(deliveryStatsSource(filter, dimension), "s").
+		GroupBy("1", "2", "status").
+		OrderBy("1", "2", "status")
+
+	err := d.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (d *deliveryQuery) RefreshDeliveryStats(ctx context.Context) error {
+	return d.db.ExecX(ctx, sq.Expr("REFRESH MATERIALIZED VIEW CONCURRENTLY "+deliveryStatsHourlyViewName))
+}
+
+// deliveryStatsSource selects hourly stats of the dimension, sender or group,
+// the same rows as delivery_stats_hourly has are computed from deliveries unless the rollup is used
+func deliveryStatsSource(filter DeliveryStatsFilter, dimension StatsGroupBy) sq.SelectBuilder {
+	if filter.UseRollup {
+		return sq.
+			Select("hour", "key_id", "status", "deliveries", "latency_sum").
+			From(deliveryStatsHourlyViewName).
+			Where(sq.Eq{"dimension": string(dimension)}).
+			Where("hour >= date_trunc('hour', ?::timestamp)", filter.From).
+			Where(sq.Lt{"hour": filter.To})
+	}
+
+	query := sq.
+		Select(
+			"date_trunc('hour', d.created_at) AS hour",
+			"d.status",
+			"count(*) AS deliveries",
+			"COALESCE(sum(extract(epoch FROM d.updated_at - d.created_at)) "+
+				"FILTER (WHERE d.status = 'DELIVERED'), 0)::double precision AS latency_sum",
+		).
+		From(deliveryTableName+" d").
+		Where(sq.GtOrEq{"d.created_at": filter.From}).
+		Where(sq.Lt{"d.created_at": filter.To}).
+		GroupBy("hour", "key_id", "d.status")
+	if dimension == StatsByGroup {
+		return query.
+			Column("m.group_id AS key_id").
+			Join(userGroupMembershipTableName + " m ON m.user_id = d.user_id")
+	}
+	return query.
+		Column("n.sender_id AS key_id").
+		Join(notificationTableName + " n ON n.id = d.notification_id")
+}
@@ -0,0 +1,67 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	deliveryTableName           = "deliveries"
+	deliveryStatsHourlyViewName = "delivery_stats_hourly"
+)
+
+// InboxTable is a notification received by a user with the delivery of the notification to the user
+type InboxTable struct {
+	NotificationTable
+	DeliveryStatus    string         `db:"delivery_status"`
+	DeliveryError     sql.NullString `db:"delivery_error"`
+	DeliveryCreatedAt time.Time      `db:"delivery_created_at"`
+	// DeliveryUpdatedAt is the time of the last status change of the delivery
+	DeliveryUpdatedAt time.Time `db:"delivery_updated_at"`
+}
+
+func (t InboxTable) columns() []string {
+	columns := make([]string, 0, len(notificationTableStom.TagValues())+4)
+	for _, column := range notificationTableStom.TagValues() {
+		columns = append(columns, "n."+column)
+	}
+	return append(columns,
+		"d.status AS delivery_status",
+		"d.error AS delivery_error",
+		"d.created_at AS delivery_created_at",
+		"d.updated_at AS delivery_updated_at",
+	)
+}
+
+// StatsGroupBy is a key delivery stats are grouped by
+type StatsGroupBy string
+
+const (
+	StatsTotal    StatsGroupBy = ""
+	StatsBySender StatsGroupBy = "sender"
+	// StatsByGroup groups deliveries by groups of receivers, a receiver in several groups is counted in each
+	StatsByGroup StatsGroupBy = "group"
+	StatsByHour  StatsGroupBy = "hour"
+	StatsByDay   StatsGroupBy = "day"
+)
+
+// DeliveryStatsFilter limits deliveries of stats by the time they are created
+type DeliveryStatsFilter struct {
+	// From is inclusive
+	From time.Time
+	// To is exclusive
+	To time.Time
+	// UseRollup reads stats from the hourly rollup, it's as fresh as its last refresh
+	// and From is truncated to the hour
+	UseRollup bool
+}
+
+// DeliveryStatsTable is the number of deliveries with the status for a key of StatsGroupBy
+type DeliveryStatsTable struct {
+	KeyID      sql.NullInt64 `db:"key_id"`
+	Period     sql.NullTime  `db:"period"`
+	Status     string        `db:"status"`
+	Deliveries int64         `db:"deliveries"`
+	// LatencySum is the sum of seconds from creation to delivery of delivered messages
+	LatencySum float64 `db:"latency_sum"`
+}
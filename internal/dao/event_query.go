@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"telegram-notification-api/internal/storage"
+)
+
+type EventQuery interface {
+	// Notify sends the payloads to listeners of the postgres channel,
+	// inside a transaction they are sent when it commits
+	Notify(ctx context.Context, channel string, payloads []string) error
+}
+
+type eventQuery struct {
+	db storage.Storage
+}
+
+func newEventQuery(db storage.Storage) EventQuery {
+	return &eventQuery{
+		db: db,
+	}
+}
+
+func (e *eventQuery) Notify(ctx context.Context, channel string, payloads []string) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	query := qb().
+		Select().
+		Column(sq.Expr("pg_notify(?, e.payload)", channel)).
+		FromSelect(
+			sq.Select().Column(sq.Expr("unnest(?::text[]) AS payload", pq.Array(payloads))),
+			"e",
+		)
+	return e.db.ExecX(ctx, query)
+}
@@ -0,0 +1,314 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"telegram-notification-api/internal/storage"
+)
+
+type GroupQuery interface {
+	GetGroup(ctx context.Context, groupID int64) (GroupMembersTable, error)
+	GetGroupByName(ctx context.Context, name string) (GroupTable, error)
+	GetGroups(ctx context.Context, includeArchived bool) ([]GroupMembersTable, error)
+	CreateGroup(
+		ctx context.Context,
+		name string,
+		description sql.NullString,
+		parentID sql.NullInt64,
+	) (GroupTable, error)
+	ChangeGroup(ctx context.Context, group GroupTable, fields ...string) (GroupTable, error)
+	DeleteGroup(ctx context.Context, groupID int64) error
+	HasSubgroups(ctx context.Context, groupID int64) (bool, error)
+	// GetAncestorIDs returns ids of all parents of the group up to the root
+	GetAncestorIDs(ctx context.Context, groupID int64) ([]int64, error)
+	// GetSubtreeMemberCounts returns the number of members of every group together with its subgroups
+	GetSubtreeMemberCounts(ctx context.Context) ([]GroupSubtreeMembersTable, error)
+	// GetMemberIDs returns ids of members of the groups and of all their subgroups
+	GetMemberIDs(ctx context.Context, groupIDs []int64) ([]int64, error)
+	AddMember(ctx context.Context, userID int64, groupID int64) error
+	RemoveMember(ctx context.Context, userID int64, groupID int64) error
+	// RemoveAllMemberships removes the user from all groups
+	RemoveAllMemberships(ctx context.Context, userID int64) error
+	AddModerator(ctx context.Context, userID int64, groupID int64) error
+	RemoveModerator(ctx context.Context, userID int64, groupID int64) error
+	// RemoveAllModerators removes the user from moderators of all groups
+	RemoveAllModerators(ctx context.Context, userID int64) error
+	// GetModeratedGroupIDs returns ids of groups moderated by the user and of all their subgroups
+	GetModeratedGroupIDs(ctx context.Context, userID int64) ([]int64, error)
+	// MoveMembers moves users of source groups to the target group
+	MoveMembers(ctx context.Context, sourceIDs []int64, targetID int64) error
+	// MoveSubgroups makes children of source groups children of the target group
+	MoveSubgroups(ctx context.Context, sourceIDs []int64, targetID int64) error
+}
+
+type groupQuery struct {
+	storage storage.Storage
+}
+
+func newGroupQuery(storage storage.Storage) GroupQuery {
+	return &groupQuery{storage: storage}
+}
+
+func (g *groupQuery) GetGroup(ctx context.Context, groupID int64) (GroupMembersTable, error) {
+	var dest GroupMembersTable
+	query := qb().
+		Select(dest.columns()...).
+		From(groupTableName).
+		Where(sq.Eq{"id": groupID})
+
+	err := g.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) GetGroupByName(ctx context.Context, name string) (GroupTable, error) {
+	var dest GroupTable
+	query := qb().
+		Select(dest.columns()...).
+		From(groupTableName).
+		Where(sq.Eq{"name": name})
+
+	err := g.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) GetGroups(ctx context.Context, includeArchived bool) ([]GroupMembersTable, error) {
+	var dest []GroupMembersTable
+	query := qb().
+		Select(GroupMembersTable{}.columns()...).
+		From(groupTableName).
+		OrderBy("name")
+
+	if !includeArchived {
+		query = query.Where(sq.Eq{"archived": false})
+	}
+
+	err := g.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) CreateGroup(
+	ctx context.Context,
+	name string,
+	description sql.NullString,
+	parentID sql.NullInt64,
+) (GroupTable, error) {
+	var dest GroupTable
+	query := qb().
+		Insert(groupTableName).
+		Columns(
+			"name",
+			"description",
+			"parent_id",
+		).
+		Values(
+			name,
+			description,
+			parentID,
+		).
+		Suffix("RETURNING *")
+
+	err := g.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) ChangeGroup(ctx context.Context, group GroupTable, fields ...string) (GroupTable, error) {
+	var dest GroupTable
+	groupMap := group.toMap()
+
+	query := qb().
+		Update(groupTableName).
+		Where(sq.Eq{"id": group.ID})
+
+	for _, field := range fields {
+		query = query.Set(field, groupMap[field])
+	}
+	query = query.Suffix("RETURNING *")
+
+	err := g.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) DeleteGroup(ctx context.Context, groupID int64) error {
+	query := qb().
+		Delete(groupTableName).
+		Where(sq.Eq{"id": groupID})
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) HasSubgroups(ctx context.Context, groupID int64) (bool, error) {
+	var dest bool
+	query := qb().
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM "+groupTableName+" WHERE parent_id = ?)", groupID))
+
+	err := g.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) GetAncestorIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Select("id").
+		Prefix(
+			`WITH RECURSIVE ancestors AS (
+				SELECT parent_id AS id FROM `+groupTableName+` WHERE id = ?
+				UNION
+				SELECT g.parent_id FROM `+groupTableName+` g JOIN ancestors a ON g.id = a.id
+			)`,
+			groupID,
+		).
+		From("ancestors").
+		Where(sq.NotEq{"id": nil})
+
+	err := g.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) GetSubtreeMemberCounts(ctx context.Context) ([]GroupSubtreeMembersTable, error) {
+	var dest []GroupSubtreeMembersTable
+	query := qb().
+		Select(
+			"s.root_id AS group_id",
+			"count(DISTINCT m.user_id) AS member_count",
+		).
+		Prefix(
+			`WITH RECURSIVE subtree(root_id, id) AS (
+				SELECT id, id FROM ` + groupTableName + `
+				UNION ALL
+				SELECT s.root_id, g.id FROM ` + groupTableName + ` g JOIN subtree s ON g.parent_id = s.id
+			)`,
+		).
+		From("subtree s").
+		Join(userGroupMembershipTableName + " m ON m.group_id = s.id").
+		Where(memberNotDeletedCondition).
+		GroupBy("s.root_id")
+
+	err := g.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) GetMemberIDs(ctx context.Context, groupIDs []int64) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Select("DISTINCT m.user_id").
+		From(userGroupMembershipTableName+" m").
+		Where("m.group_id IN ("+groupSubtreeQuery("id = ANY(?)")+")", pq.Array(groupIDs)).
+		Where(memberNotDeletedCondition)
+
+	err := g.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) AddMember(ctx context.Context, userID int64, groupID int64) error {
+	query := qb().
+		Insert(userGroupMembershipTableName).
+		Columns(
+			"user_id",
+			"group_id",
+		).
+		Values(
+			userID,
+			groupID,
+		).
+		Suffix("ON CONFLICT DO NOTHING")
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) RemoveMember(ctx context.Context, userID int64, groupID int64) error {
+	query := qb().
+		Delete(userGroupMembershipTableName).
+		Where(sq.Eq{
+			"user_id":  userID,
+			"group_id": groupID,
+		})
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) RemoveAllMemberships(ctx context.Context, userID int64) error {
+	query := qb().
+		Delete(userGroupMembershipTableName).
+		Where(sq.Eq{"user_id": userID})
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) AddModerator(ctx context.Context, userID int64, groupID int64) error {
+	query := qb().
+		Insert(groupModeratorTableName).
+		Columns(
+			"user_id",
+			"group_id",
+		).
+		Values(
+			userID,
+			groupID,
+		).
+		Suffix("ON CONFLICT DO NOTHING")
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) RemoveModerator(ctx context.Context, userID int64, groupID int64) error {
+	query := qb().
+		Delete(groupModeratorTableName).
+		Where(sq.Eq{
+			"user_id":  userID,
+			"group_id": groupID,
+		})
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) RemoveAllModerators(ctx context.Context, userID int64) error {
+	query := qb().
+		Delete(groupModeratorTableName).
+		Where(sq.Eq{"user_id": userID})
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) GetModeratedGroupIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Select("id").
+		From(groupTableName).
+		Where(
+			"id IN ("+groupSubtreeQuery("id IN (SELECT group_id FROM "+groupModeratorTableName+" WHERE user_id = ?)")+")",
+			userID,
+		)
+
+	err := g.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (g *groupQuery) MoveMembers(ctx context.Context, sourceIDs []int64, targetID int64) error {
+	insert := qb().
+		Insert(userGroupMembershipTableName).
+		Columns(
+			"user_id",
+			"group_id",
+		).
+		Select(
+			qb().
+				Select("DISTINCT user_id").
+				Column(sq.Expr("?::bigint", targetID)).
+				From(userGroupMembershipTableName).
+				Where("group_id = ANY(?)", pq.Array(sourceIDs)),
+		).
+		Suffix("ON CONFLICT DO NOTHING")
+	if err := g.storage.ExecX(ctx, insert); err != nil {
+		return err
+	}
+
+	query := qb().
+		Delete(userGroupMembershipTableName).
+		Where("group_id = ANY(?)", pq.Array(sourceIDs))
+	return g.storage.ExecX(ctx, query)
+}
+
+func (g *groupQuery) MoveSubgroups(ctx context.Context, sourceIDs []int64, targetID int64) error {
+	query := qb().
+		Update(groupTableName).
+		Set("parent_id", targetID).
+		Where("parent_id = ANY(?)", pq.Array(sourceIDs)).
+		Where(sq.NotEq{"id": targetID})
+	return g.storage.ExecX(ctx, query)
+}
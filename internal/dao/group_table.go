@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"database/sql"
+	"github.com/elgris/stom"
+)
+
+const (
+	groupTableName               = "groups"
+	userGroupMembershipTableName = "user_group_memberships"
+	groupModeratorTableName      = "group_moderators"
+)
+
+type GroupTable struct {
+	ID          int64          `db:"id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	ParentID    sql.NullInt64  `db:"parent_id"`
+	Archived    bool           `db:"archived"`
+}
+
+// GroupMembersTable is a group with the number of users in it
+type GroupMembersTable struct {
+	GroupTable
+	MemberCount int64 `db:"member_count"`
+}
+
+// GroupSubtreeMembersTable is the number of distinct users in the group and all its subgroups
+type GroupSubtreeMembersTable struct {
+	GroupID     int64 `db:"group_id"`
+	MemberCount int64 `db:"member_count"`
+}
+
+var groupTableStom = stom.MustNewStom(GroupTable{})
+
+func (g GroupTable) columns() []string {
+	return groupTableStom.TagValues()
+}
+
+func (g GroupTable) toMap() map[string]interface{} {
+	m, err := groupTableStom.ToMap(g)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (g GroupMembersTable) columns() []string {
+	return append(
+		g.GroupTable.columns(),
+		"(SELECT count(*) FROM "+userGroupMembershipTableName+" m WHERE m.group_id = "+groupTableName+
+			".id AND "+memberNotDeletedCondition+") AS member_count",
+	)
+}
+
+// groupSubtreeQuery selects ids of groups matching the condition and ids of all their subgroups
+func groupSubtreeQuery(condition string) string {
+	return "WITH RECURSIVE subtree AS (" +
+		"SELECT id FROM " + groupTableName + " WHERE " + condition +
+		" UNION SELECT g.id FROM " + groupTableName + " g JOIN subtree s ON g.parent_id = s.id" +
+		") SELECT id FROM subtree"
+}
@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"strings"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/storage"
+	"time"
+)
+
+type LinkQuery interface {
+	// CreateLinks adds tracked links of the notification, urls must be distinct
+	CreateLinks(ctx context.Context, notificationID int64, urls []string) ([]LinkTable, error)
+	GetLink(ctx context.Context, linkID int64) (LinkTable, error)
+	AddClick(ctx context.Context, linkID int64, userID int64, clickedAt time.Time) error
+	GetNotificationEngagement(ctx context.Context, notificationIDs []int64) ([]NotificationEngagementTable, error)
+	GetLinkEngagement(ctx context.Context, notificationIDs []int64) ([]LinkEngagementTable, error)
+	// GetGroupEngagement counts deliveries of the notifications by groups of receivers,
+	// a receiver in several groups is counted in each of them
+	GetGroupEngagement(ctx context.Context, notificationIDs []int64) ([]GroupEngagementTable, error)
+}
+
+type linkQuery struct {
+	db storage.Storage
+}
+
+func newLinkQuery(db storage.Storage) LinkQuery {
+	return &linkQuery{
+		db: db,
+	}
+}
+
+func (l *linkQuery) CreateLinks(ctx context.Context, notificationID int64, urls []string) ([]LinkTable, error) {
+	var dest []LinkTable
+	query := qb().
+		Insert(trackedLinkTableName).
+		Columns(
+			"notification_id",
+			"url",
+		)
+	for _, url := range urls {
+		query = query.Values(notificationID, url)
+	}
+	query = query.Suffix("RETURNING " + strings.Join(LinkTable{}.columns(), ", "))
+
+	err := l.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (l *linkQuery) GetLink(ctx context.Context, linkID int64) (LinkTable, error) {
+	var dest LinkTable
+	query := qb().
+		Select(dest.columns()...).
+		From(trackedLinkTableName).
+		Where(sq.Eq{"id": linkID})
+
+	err := l.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (l *linkQuery) AddClick(ctx context.Context, linkID int64, userID int64, clickedAt time.Time) error {
+	query := qb().
+		Insert(linkClickTableName).
+		Columns(
+			"link_id",
+			"user_id",
+			"clicked_at",
+		).
+		Values(
+			linkID,
+			userID,
+			clickedAt,
+		)
+	return l.db.ExecX(ctx, query)
+}
+
+func (l *linkQuery) GetNotificationEngagement(
+	ctx context.Context,
+	notificationIDs []int64,
+) ([]NotificationEngagementTable, error) {
+	var dest []NotificationEngagementTable
+	query := qb().
+		Select(
+			"n.id AS notification_id",
+			"(SELECT count(*) FROM "+deliveryTableName+" d "+
+				"WHERE d.notification_id = n.id AND d.status = '"+desc.DeliveryStatus_DELIVERED.String()+"') AS delivered",
+			"count(DISTINCT c.user_id) AS clicked",
+			"count(c.id) AS clicks",
+		).
+		From(notificationTableName+" n").
+		LeftJoin(trackedLinkTableName+" l ON l.notification_id = n.id").
+		LeftJoin(linkClickTableName+" c ON c.link_id = l.id").
+		Where("n.id = ANY(?)", pq.Array(notificationIDs)).
+		GroupBy("n.id").
+		OrderBy("n.id")
+
+	err := l.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (l *linkQuery) GetLinkEngagement(ctx context.Context, notificationIDs []int64) ([]LinkEngagementTable, error) {
+	var dest []LinkEngagementTable
+	query := qb().
+		Select(
+			"l.id",
+			"l.notification_id",
+			"l.url",
+			"count(DISTINCT c.user_id) AS clicked",
+			"count(c.id) AS clicks",
+		).
+		From(trackedLinkTableName+" l").
+		LeftJoin(linkClickTableName+" c ON c.link_id = l.id").
+		Where("l.notification_id = ANY(?)", pq.Array(notificationIDs)).
+		GroupBy("l.id").
+		OrderBy("l.notification_id", "l.id")
+
+	err := l.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (l *linkQuery) GetGroupEngagement(ctx context.Context, notificationIDs []int64) ([]GroupEngagementTable, error) {
+	var dest []GroupEngagementTable
+	query := qb().
+		Select(
+			"m.group_id",
+			"count(*) AS delivered",
+			"count(*) FILTER (WHERE EXISTS ("+
+				"SELECT 1 FROM "+linkClickTableName+" c JOIN "+trackedLinkTableName+" l ON l.id = c.link_id "+
+				"WHERE l.notification_id = d.notification_id AND c.user_id = d.user_id"+
+				")) AS clicked",
+		).
+		From(deliveryTableName+" d").
+		Join(userGroupMembershipTableName+" m ON m.user_id = d.user_id").
+		Where("d.notification_id = ANY(?)", pq.Array(notificationIDs)).
+		Where(sq.Eq{"d.status": desc.DeliveryStatus_DELIVERED.String()}).
+		GroupBy("m.group_id").
+		OrderBy("m.group_id")
+
+	err := l.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
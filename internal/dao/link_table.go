@@ -0,0 +1,45 @@
+package dao
+
+import "github.com/elgris/stom"
+
+const (
+	trackedLinkTableName = "tracked_links"
+	linkClickTableName   = "link_clicks"
+)
+
+// LinkTable is a link of a notification that is replaced with a redirect recording clicks
+type LinkTable struct {
+	ID             int64  `db:"id"`
+	NotificationID int64  `db:"notification_id"`
+	URL            string `db:"url"`
+}
+
+// NotificationEngagementTable counts clicks of tracked links of a notification
+type NotificationEngagementTable struct {
+	NotificationID int64 `db:"notification_id"`
+	// Delivered is the number of receivers the notification is delivered to
+	Delivered int64 `db:"delivered"`
+	// Clicked is the number of receivers who clicked any link
+	Clicked int64 `db:"clicked"`
+	Clicks  int64 `db:"clicks"`
+}
+
+// LinkEngagementTable counts clicks of a tracked link
+type LinkEngagementTable struct {
+	LinkTable
+	Clicked int64 `db:"clicked"`
+	Clicks  int64 `db:"clicks"`
+}
+
+// GroupEngagementTable counts messages delivered to members of a group and clicked by them
+type GroupEngagementTable struct {
+	GroupID   int64 `db:"group_id"`
+	Delivered int64 `db:"delivered"`
+	Clicked   int64 `db:"clicked"`
+}
+
+var linkTableStom = stom.MustNewStom(LinkTable{})
+
+func (t LinkTable) columns() []string {
+	return linkTableStom.TagValues()
+}
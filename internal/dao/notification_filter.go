@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// NotificationFilter describes conditions for GetNotificationsByFilter, zero values don't limit the result
+type NotificationFilter struct {
+	SenderID sql.NullInt64
+	// ReceiverID matches notifications with the id among receiver ids
+	ReceiverID sql.NullInt64
+	// Statuses matches notifications with any of the statuses
+	Statuses []string
+	From     sql.NullTime
+	To       sql.NullTime
+	HasMedia sql.NullBool
+	// Text is a case-insensitive part of the message
+	Text string
+}
+
+func (f NotificationFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	if f.SenderID.Valid {
+		query = query.Where(sq.Eq{"sender_id": f.SenderID.Int64})
+	}
+	if f.ReceiverID.Valid {
+		query = query.Where("receiver_ids @> ARRAY[?::bigint]", f.ReceiverID.Int64)
+	}
+	if len(f.Statuses) > 0 {
+		query = query.Where("status = ANY(?)", pq.Array(f.Statuses))
+	}
+	if f.From.Valid {
+		query = query.Where(sq.GtOrEq{"date": f.From.Time})
+	}
+	if f.To.Valid {
+		query = query.Where(sq.Lt{"date": f.To.Time})
+	}
+	if f.HasMedia.Valid && f.HasMedia.Bool {
+		query = query.Where("media_content IS NOT NULL")
+	} else if f.HasMedia.Valid {
+		query = query.Where("media_content IS NULL")
+	}
+	if f.Text != "" {
+		query = query.Where(sq.ILike{"message": "%" + escapeLike(f.Text) + "%"})
+	}
+	return query
+}
@@ -19,18 +19,44 @@ type NotificationQuery interface {
 		message string,
 		mediaContent sql.NullString,
 		date time.Time,
+		topicID sql.NullInt64,
 	) (NotificationTable, error)
 	GetNotifications(
 		ctx context.Context,
 		IDs []int64,
+		orderBy []OrderBy,
 		limit uint64,
 		offset uint64,
 	) ([]NotificationTable, error)
+	// GetNotificationsAfter returns notifications after the position of the keyset in its order
+	GetNotificationsAfter(ctx context.Context, IDs []int64, keyset Keyset, limit uint64) ([]NotificationTable, error)
+	CountNotifications(ctx context.Context, IDs []int64) (int64, error)
+	// GetNotificationsByFilter returns notifications from newest to oldest when orderBy is empty
+	GetNotificationsByFilter(
+		ctx context.Context,
+		filter NotificationFilter,
+		orderBy []OrderBy,
+		limit uint64,
+		offset uint64,
+	) ([]NotificationTable, error)
+	// GetNotificationsByFilterAfter returns notifications after the position of the keyset in its order
+	GetNotificationsByFilterAfter(
+		ctx context.Context,
+		filter NotificationFilter,
+		keyset Keyset,
+		limit uint64,
+	) ([]NotificationTable, error)
+	CountNotificationsByFilter(ctx context.Context, filter NotificationFilter) (int64, error)
 	UpdateNotificationStatus(
 		ctx context.Context,
 		notificationID int64,
 		status string,
 	) error
+	GetNotificationsBySender(ctx context.Context, senderID int64) ([]NotificationTable, error)
+	GetNotificationsByReceiver(ctx context.Context, receiverID int64) ([]NotificationTable, error)
+	// EraseMessages replaces bodies of notifications sent by the user or sent only to the user
+	// with a tombstone and returns ids of changed notifications
+	EraseMessages(ctx context.Context, userID int64, tombstone string) ([]int64, error)
 }
 
 type notificationQuery struct {
@@ -64,6 +90,7 @@ func (n *notificationQuery) CreateNotification(
 	message string,
 	mediaContent sql.NullString,
 	date time.Time,
+	topicID sql.NullInt64,
 ) (NotificationTable, error) {
 	var dest NotificationTable
 
@@ -76,6 +103,7 @@ func (n *notificationQuery) CreateNotification(
 			"media_content",
 			"date",
 			"status",
+			"topic_id",
 		).
 		Values(
 			senderID,
@@ -84,6 +112,7 @@ func (n *notificationQuery) CreateNotification(
 			mediaContent,
 			date,
 			desc.NotificationStatus_CREATED.String(),
+			topicID,
 		).
 		Suffix("RETURNING *")
 
@@ -94,6 +123,7 @@ func (n *notificationQuery) CreateNotification(
 func (n *notificationQuery) GetNotifications(
 	ctx context.Context,
 	IDs []int64,
+	orderBy []OrderBy,
 	limit uint64,
 	offset uint64,
 ) ([]NotificationTable, error) {
@@ -101,7 +131,8 @@ func (n *notificationQuery) GetNotifications(
 	query := qb().
 		Select(NotificationTable{}.columns()...).
 		From(notificationTableName).
-		Where("id = ANY(?)", pq.Array(IDs)).
+		Where("id = ANY(?)", pq.Array(IDs))
+	query = applyOrderBy(query, orderBy, "id").
 		Offset(offset).
 		Limit(limit)
 
@@ -109,6 +140,35 @@ func (n *notificationQuery) GetNotifications(
 	return dest, err
 }
 
+func (n *notificationQuery) GetNotificationsAfter(
+	ctx context.Context,
+	IDs []int64,
+	keyset Keyset,
+	limit uint64,
+) ([]NotificationTable, error) {
+	var dest []NotificationTable
+	query := qb().
+		Select(NotificationTable{}.columns()...).
+		From(notificationTableName).
+		Where("id = ANY(?)", pq.Array(IDs))
+	query = applyKeyset(query, keyset, notificationTableName).
+		Limit(limit)
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) CountNotifications(ctx context.Context, IDs []int64) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("count(*)").
+		From(notificationTableName).
+		Where("id = ANY(?)", pq.Array(IDs))
+
+	err := n.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
 func (n *notificationQuery) UpdateNotificationStatus(
 	ctx context.Context,
 	notificationID int64,
@@ -120,3 +180,98 @@ func (n *notificationQuery) UpdateNotificationStatus(
 		Where(sq.Eq{"id": notificationID})
 	return n.db.ExecX(ctx, query)
 }
+
+func (n *notificationQuery) GetNotificationsBySender(ctx context.Context, senderID int64) ([]NotificationTable, error) {
+	var dest []NotificationTable
+	query := qb().
+		Select(NotificationTable{}.columns()...).
+		From(notificationTableName).
+		Where(sq.Eq{"sender_id": senderID}).
+		OrderBy("date", "id")
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) GetNotificationsByReceiver(ctx context.Context, receiverID int64) ([]NotificationTable, error) {
+	var dest []NotificationTable
+	query := qb().
+		Select(NotificationTable{}.columns()...).
+		From(notificationTableName).
+		Where("receiver_ids @> ARRAY[?::bigint]", receiverID).
+		OrderBy("date", "id")
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) EraseMessages(ctx context.Context, userID int64, tombstone string) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Update(notificationTableName).
+		Set("message", tombstone).
+		Set("media_content", nil).
+		Where(sq.Or{
+			sq.Eq{"sender_id": userID},
+			sq.Expr("receiver_ids = ARRAY[?::bigint]", userID),
+		}).
+		Suffix("RETURNING id")
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) GetNotificationsByFilter(
+	ctx context.Context,
+	filter NotificationFilter,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]NotificationTable, error) {
+	var dest []NotificationTable
+	if len(orderBy) == 0 {
+		orderBy = []OrderBy{{Column: "id", Desc: true}}
+	}
+	query := filter.apply(
+		qb().
+			Select(NotificationTable{}.columns()...).
+			From(notificationTableName),
+	)
+	query = applyOrderBy(query, orderBy, "id").
+		Limit(limit).
+		Offset(offset)
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) GetNotificationsByFilterAfter(
+	ctx context.Context,
+	filter NotificationFilter,
+	keyset Keyset,
+	limit uint64,
+) ([]NotificationTable, error) {
+	var dest []NotificationTable
+	query := filter.apply(
+		qb().
+			Select(NotificationTable{}.columns()...).
+			From(notificationTableName),
+	)
+	query = applyKeyset(query, keyset, notificationTableName).
+		Limit(limit)
+
+	err := n.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (n *notificationQuery) CountNotificationsByFilter(ctx context.Context, filter NotificationFilter) (int64, error) {
+	var dest int64
+	query := filter.apply(
+		qb().
+			Select("count(*)").
+			From(notificationTableName),
+	)
+
+	err := n.db.GetX(ctx, &dest, query)
+	return dest, err
+}
@@ -15,6 +15,7 @@ type NotificationTable struct {
 	MediaContent sql.NullString `db:"media_content"`
 	Status       string         `db:"status"`
 	Date         time.Time      `db:"date"`
+	TopicID      sql.NullInt64  `db:"topic_id"`
 }
 
 const (
@@ -27,6 +28,11 @@ func (t NotificationTable) columns() []string {
 	return notificationTableStom.TagValues()
 }
 
+// Keyset returns the position of the notification in a list ordered by orderBy
+func (t NotificationTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(t.toMap(), orderBy)
+}
+
 func (t NotificationTable) toMap() map[string]interface{} {
 	m, err := notificationTableStom.ToMap(t)
 	if err != nil {
@@ -0,0 +1,99 @@
+package dao
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// OrderBy is a sort key of a list, Column is put into the query as is and must come from a whitelist
+type OrderBy struct {
+	Column string
+	Desc   bool
+}
+
+// applyOrderBy sorts by the keys and then by idColumn unless it's one of the keys, so pages are stable
+func applyOrderBy(query sq.SelectBuilder, orderBy []OrderBy, idColumn string) sq.SelectBuilder {
+	for _, key := range orderBy {
+		if key.Desc {
+			query = query.OrderBy(key.Column + " DESC")
+		} else {
+			query = query.OrderBy(key.Column)
+		}
+	}
+	if !slices.ContainsFunc(orderBy, func(key OrderBy) bool { return key.Column == idColumn }) {
+		query = query.OrderBy(idColumn)
+	}
+	return query
+}
+
+// Keyset is the position of an item in a list ordered by OrderBy and then by id,
+// the next page of such a list is selected after the position instead of skipping an offset.
+// Ties are ordered by id ascending like in applyOrderBy, so both ways to page a list give the same order.
+type Keyset struct {
+	// OrderBy is empty for lists ordered only by id, its column must be not null
+	OrderBy OrderBy
+	// Value is the value of the OrderBy column of the item as text, postgres casts it to the type of the column
+	Value string
+	// AfterID is the id of the item, 0 selects the first page
+	AfterID int64
+}
+
+// newKeyset returns the position of the row in a list ordered by orderBy
+func newKeyset(row map[string]interface{}, orderBy OrderBy) Keyset {
+	keyset := Keyset{OrderBy: orderBy}
+	keyset.AfterID, _ = row["id"].(int64)
+	if !keyset.orderedByID() {
+		keyset.Value = keysetValue(row[orderBy.Column])
+	}
+	return keyset
+}
+
+func keysetValue(value interface{}) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		value, _ = valuer.Value()
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+func (k Keyset) orderedByID() bool {
+	return k.OrderBy.Column == "" || k.OrderBy.Column == "id"
+}
+
+// applyKeyset sorts rows of the table by the keyset order and leaves rows after the position of the keyset
+func applyKeyset(query sq.SelectBuilder, keyset Keyset, table string) sq.SelectBuilder {
+	idColumn := table + ".id"
+	if keyset.orderedByID() {
+		comparison, direction := ">", ""
+		if keyset.OrderBy.Desc {
+			comparison, direction = "<", " DESC"
+		}
+		if keyset.AfterID > 0 {
+			query = query.Where(idColumn+" "+comparison+" ?", keyset.AfterID)
+		}
+		return query.OrderBy(idColumn + direction)
+	}
+
+	column := table + "." + keyset.OrderBy.Column
+	if keyset.OrderBy.Desc {
+		if keyset.AfterID > 0 {
+			query = query.Where(
+				"("+column+" < ? OR ("+column+" = ? AND "+idColumn+" > ?))",
+				keyset.Value,
+				keyset.Value,
+				keyset.AfterID,
+			)
+		}
+		return query.OrderBy(column+" DESC", idColumn)
+	}
+	if keyset.AfterID > 0 {
+		query = query.Where("("+column+", "+idColumn+") > (?, ?)", keyset.Value, keyset.AfterID)
+	}
+	return query.OrderBy(column, idColumn)
+}
@@ -0,0 +1,188 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"telegram-notification-api/internal/storage"
+)
+
+type TopicQuery interface {
+	GetTopic(ctx context.Context, topicID int64) (TopicTable, error)
+	GetTopics(ctx context.Context, orderBy []OrderBy, limit uint64, offset uint64) ([]TopicTable, error)
+	// GetTopicsAfter returns topics after the position of the keyset in its order
+	GetTopicsAfter(ctx context.Context, keyset Keyset, limit uint64) ([]TopicTable, error)
+	CountTopics(ctx context.Context) (int64, error)
+	CreateTopic(ctx context.Context, name string, description sql.NullString) (TopicTable, error)
+	ChangeTopic(ctx context.Context, topic TopicTable, fields ...string) (TopicTable, error)
+	DeleteTopic(ctx context.Context, topicID int64) error
+	Subscribe(ctx context.Context, userID int64, topicID int64) error
+	Unsubscribe(ctx context.Context, userID int64, topicID int64) error
+	// GetSubscriptions returns topics the user is subscribed to
+	GetSubscriptions(ctx context.Context, userID int64) ([]TopicTable, error)
+	// UnsubscribeFromAll removes all subscriptions of the user
+	UnsubscribeFromAll(ctx context.Context, userID int64) error
+	// GetSubscribedUserIDs returns those of userIDs that are subscribed to the topic
+	GetSubscribedUserIDs(ctx context.Context, topicID int64, userIDs []int64) ([]int64, error)
+}
+
+type topicQuery struct {
+	storage storage.Storage
+}
+
+func newTopicQuery(storage storage.Storage) TopicQuery {
+	return &topicQuery{storage: storage}
+}
+
+func (t *topicQuery) GetTopic(ctx context.Context, topicID int64) (TopicTable, error) {
+	var dest TopicTable
+	query := qb().
+		Select(dest.columns()...).
+		From(topicTableName).
+		Where(sq.Eq{"id": topicID})
+
+	err := t.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) GetTopics(
+	ctx context.Context,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]TopicTable, error) {
+	var dest []TopicTable
+	query := qb().
+		Select(TopicTable{}.columns()...).
+		From(topicTableName)
+	query = applyOrderBy(query, orderBy, "id").
+		Limit(limit).
+		Offset(offset)
+
+	err := t.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) GetTopicsAfter(ctx context.Context, keyset Keyset, limit uint64) ([]TopicTable, error) {
+	var dest []TopicTable
+	query := qb().
+		Select(TopicTable{}.columns()...).
+		From(topicTableName)
+	query = applyKeyset(query, keyset, topicTableName).
+		Limit(limit)
+
+	err := t.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) CountTopics(ctx context.Context) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("count(*)").
+		From(topicTableName)
+
+	err := t.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) CreateTopic(ctx context.Context, name string, description sql.NullString) (TopicTable, error) {
+	var dest TopicTable
+	query := qb().
+		Insert(topicTableName).
+		Columns(
+			"name",
+			"description",
+		).
+		Values(
+			name,
+			description,
+		).
+		Suffix("RETURNING *")
+
+	err := t.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) ChangeTopic(ctx context.Context, topic TopicTable, fields ...string) (TopicTable, error) {
+	var dest TopicTable
+	topicMap := topic.toMap()
+
+	query := qb().
+		Update(topicTableName).
+		Where(sq.Eq{"id": topic.ID})
+
+	for _, field := range fields {
+		query = query.Set(field, topicMap[field])
+	}
+	query = query.Suffix("RETURNING *")
+
+	err := t.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) DeleteTopic(ctx context.Context, topicID int64) error {
+	query := qb().
+		Delete(topicTableName).
+		Where(sq.Eq{"id": topicID})
+	return t.storage.ExecX(ctx, query)
+}
+
+func (t *topicQuery) Subscribe(ctx context.Context, userID int64, topicID int64) error {
+	query := qb().
+		Insert(userTopicSubscriptionTableName).
+		Columns(
+			"user_id",
+			"topic_id",
+		).
+		Values(
+			userID,
+			topicID,
+		).
+		Suffix("ON CONFLICT DO NOTHING")
+	return t.storage.ExecX(ctx, query)
+}
+
+func (t *topicQuery) Unsubscribe(ctx context.Context, userID int64, topicID int64) error {
+	query := qb().
+		Delete(userTopicSubscriptionTableName).
+		Where(sq.Eq{
+			"user_id":  userID,
+			"topic_id": topicID,
+		})
+	return t.storage.ExecX(ctx, query)
+}
+
+func (t *topicQuery) GetSubscriptions(ctx context.Context, userID int64) ([]TopicTable, error) {
+	var dest []TopicTable
+	query := qb().
+		Select(TopicTable{}.columns()...).
+		From(topicTableName).
+		Where(
+			"id IN (SELECT topic_id FROM "+userTopicSubscriptionTableName+" WHERE user_id = ?)",
+			userID,
+		).
+		OrderBy("id")
+
+	err := t.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (t *topicQuery) UnsubscribeFromAll(ctx context.Context, userID int64) error {
+	query := qb().
+		Delete(userTopicSubscriptionTableName).
+		Where(sq.Eq{"user_id": userID})
+	return t.storage.ExecX(ctx, query)
+}
+
+func (t *topicQuery) GetSubscribedUserIDs(ctx context.Context, topicID int64, userIDs []int64) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Select("user_id").
+		From(userTopicSubscriptionTableName).
+		Where(sq.Eq{"topic_id": topicID}).
+		Where("user_id = ANY(?)", pq.Array(userIDs))
+
+	err := t.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"database/sql"
+	"github.com/elgris/stom"
+)
+
+const (
+	topicTableName                 = "topics"
+	userTopicSubscriptionTableName = "user_topic_subscriptions"
+)
+
+type TopicTable struct {
+	ID          int64          `db:"id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+}
+
+var topicTableStom = stom.MustNewStom(TopicTable{})
+
+func (t TopicTable) columns() []string {
+	return topicTableStom.TagValues()
+}
+
+// Keyset returns the position of the topic in a list ordered by orderBy
+func (t TopicTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(t.toMap(), orderBy)
+}
+
+func (t TopicTable) toMap() map[string]interface{} {
+	m, err := topicTableStom.ToMap(t)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,133 @@
+package dao
+
+import (
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+// TextMatch is how text fields of UserFilter are matched
+type TextMatch string
+
+const (
+	TextMatchExact    TextMatch = "EXACT"
+	TextMatchPrefix   TextMatch = "PREFIX"
+	TextMatchContains TextMatch = "CONTAINS"
+)
+
+// userTextFields are fields of UserFilter matched according to TextMatch and CaseInsensitive
+var userTextFields = []string{"firstname", "surname", "patronymic", "mobile_phone"}
+
+// UserFilter describes conditions for GetUserByFilter.
+// Fields lists columns of User that must be equal to the values of User,
+// text fields are matched according to TextMatch and CaseInsensitive instead.
+type UserFilter struct {
+	User   UserTable
+	Fields []string
+
+	// TopicID limits result to users subscribed to the topic
+	TopicID sql.NullInt64
+	// Groups limits result to members of any of the groups
+	Groups []string
+	// IncludeSubgroups makes Groups match members of subgroups too
+	IncludeSubgroups bool
+	// IncludeDeleted makes soft deleted users match too
+	IncludeDeleted bool
+
+	// TextMatch applies to text fields of Fields, empty means TextMatchExact
+	TextMatch       TextMatch
+	CaseInsensitive bool
+	// Query is a free text, every word of it must be a part of fio or mobile phone
+	Query string
+}
+
+func (f UserFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	userMap := f.User.toMap()
+	for _, field := range f.Fields {
+		value := userMap[field]
+		if patronymic, ok := value.(sql.NullString); ok && patronymic.Valid {
+			value = patronymic.String
+		}
+		text, ok := value.(string)
+		if ok && slices.Contains(userTextFields, field) && f.matchesText() {
+			query = query.Where(f.textCondition(field, text))
+			continue
+		}
+		query = query.Where(sq.Eq{field: value})
+	}
+	for _, word := range f.queryWords() {
+		query = query.Where(sq.Like{userSearchExpression: "%" + escapeLike(word) + "%"})
+	}
+
+	if !f.IncludeDeleted {
+		query = query.Where(userNotDeletedCondition)
+	}
+	if f.TopicID.Valid {
+		query = query.Where(
+			sq.Expr(
+				"EXISTS (SELECT 1 FROM "+userTopicSubscriptionTableName+
+					" s WHERE s.user_id = "+userTableName+".id AND s.topic_id = ?)",
+				f.TopicID.Int64,
+			),
+		)
+	}
+	if len(f.Groups) > 0 && f.IncludeSubgroups {
+		query = query.Where(
+			sq.Expr(
+				"EXISTS (SELECT 1 FROM "+userGroupMembershipTableName+" m WHERE m.user_id = "+userTableName+
+					".id AND m.group_id IN ("+groupSubtreeQuery("name = ANY(?)")+"))",
+				pq.Array(f.Groups),
+			),
+		)
+	} else if len(f.Groups) > 0 {
+		query = query.Where(
+			sq.Expr(
+				"EXISTS (SELECT 1 FROM "+userGroupMembershipTableName+" m JOIN "+groupTableName+
+					" g ON g.id = m.group_id WHERE m.user_id = "+userTableName+".id AND g.name = ANY(?))",
+				pq.Array(f.Groups),
+			),
+		)
+	}
+	return query
+}
+
+// orderByRelevance puts users that are closer to Query first
+func (f UserFilter) orderByRelevance(query sq.SelectBuilder) sq.SelectBuilder {
+	words := f.queryWords()
+	if len(words) == 0 {
+		return query
+	}
+	return query.OrderByClause("word_similarity(?, "+userSearchExpression+") DESC", strings.Join(words, " "))
+}
+
+func (f UserFilter) queryWords() []string {
+	return strings.Fields(strings.ToLower(f.Query))
+}
+
+// matchesText reports whether text fields need other conditions than equality
+func (f UserFilter) matchesText() bool {
+	return f.CaseInsensitive || (f.TextMatch != "" && f.TextMatch != TextMatchExact)
+}
+
+func (f UserFilter) textCondition(field string, value string) sq.Sqlizer {
+	pattern := escapeLike(value)
+	switch f.TextMatch {
+	case TextMatchPrefix:
+		pattern = pattern + "%"
+	case TextMatchContains:
+		pattern = "%" + pattern + "%"
+	}
+	if f.CaseInsensitive {
+		return sq.ILike{field: pattern}
+	}
+	return sq.Like{field: pattern}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes value match itself in a LIKE pattern
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
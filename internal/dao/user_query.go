@@ -3,36 +3,85 @@ package dao
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
+	"strings"
 	"telegram-notification-api/internal/storage"
 )
 
 type UserQuery interface {
+	// GetUser returns the user if it's not deleted
 	GetUser(ctx context.Context, userID int64) (UserTable, error)
-	GetUsersByIds(ctx context.Context, userIDs []int64, limit uint64, offset uint64) ([]UserTable, error)
+	GetUserIncludingDeleted(ctx context.Context, userID int64) (UserTable, error)
+	// GetUsersByTelegramIds returns users with the telegram ids that are not deleted
+	GetUsersByTelegramIds(ctx context.Context, telegramIDs []int64) ([]UserTable, error)
+	GetUsersByIds(
+		ctx context.Context,
+		userIDs []int64,
+		includeDeleted bool,
+		orderBy []OrderBy,
+		limit uint64,
+		offset uint64,
+	) ([]UserTable, error)
+	// GetUsersByIdsAfter returns users after the position of the keyset in its order
+	GetUsersByIdsAfter(
+		ctx context.Context,
+		userIDs []int64,
+		includeDeleted bool,
+		keyset Keyset,
+		limit uint64,
+	) ([]UserTable, error)
+	CountUsersByIds(ctx context.Context, userIDs []int64, includeDeleted bool) (int64, error)
 	CreateUser(
 		ctx context.Context,
 		telegramID int64,
 		userRole string,
 		userNotificationStatus string,
-		group string,
 		firstname string,
 		lastname string,
 		patronymic sql.NullString,
 		mobilePhone string,
 		userStatus string,
 	) (UserTable, error)
-	DeleteUser(ctx context.Context, userID int64) error
+	// DeleteUser marks the user as deleted, data of the user is kept to restore it,
+	// sql.ErrNoRows is returned if there is no such user or it's already deleted
+	DeleteUser(ctx context.Context, userID int64, deletedBy sql.NullInt64) error
+	RestoreUser(ctx context.Context, userID int64) error
+	// PurgeUser replaces personal data of the user with placeholders and marks it as deleted,
+	// the row stays to keep notifications of the user consistent
+	PurgeUser(ctx context.Context, userID int64) error
+	// ChangeUser and the other Change methods never touch deleted users
 	ChangeUser(ctx context.Context, user UserTable, fields ...string) (UserTable, error)
+	// ChangeUsers sets fields of every user with the ids to the values of user in one statement
+	ChangeUsers(ctx context.Context, user UserTable, userIDs []int64, fields ...string) ([]UserTable, error)
+	// ChangeEachUser sets fields of every user to its own values in one statement
+	ChangeEachUser(ctx context.Context, users []UserTable, fields ...string) ([]UserTable, error)
+	// CreateUsers inserts users in one statement, the order of the result is not defined
+	CreateUsers(ctx context.Context, users []UserTable) ([]UserTable, error)
+	// DeleteUsers marks users as deleted in one statement and returns ids of the users it deleted
+	DeleteUsers(ctx context.Context, userIDs []int64, deletedBy sql.NullInt64) ([]int64, error)
+	// GetUserByFilter orders users by relevance to the query of the filter when orderBy is empty
 	GetUserByFilter(
 		ctx context.Context,
-		user UserTable,
+		filter UserFilter,
+		orderBy []OrderBy,
 		limit uint64,
 		offset uint64,
-		fields ...string,
 	) ([]UserTable, error)
-	GetGroups(ctx context.Context) ([]string, error)
+	CountUsersByFilter(ctx context.Context, filter UserFilter) (int64, error)
+	// GetDuplicates returns values of unique fields shared by several not deleted users,
+	// such users are left from the time the fields were not unique
+	GetDuplicates(ctx context.Context) ([]UserDuplicateTable, error)
+	// GetUsersByFilterAfter returns users after the position of the keyset in its order,
+	// it's used to walk through all matching users page by page
+	GetUsersByFilterAfter(
+		ctx context.Context,
+		filter UserFilter,
+		keyset Keyset,
+		limit uint64,
+	) ([]UserTable, error)
 }
 
 type userQuery struct {
@@ -44,6 +93,18 @@ func newUserQuery(storage storage.Storage) UserQuery {
 }
 
 func (u *userQuery) GetUser(ctx context.Context, userID int64) (UserTable, error) {
+	var dest UserTable
+	query := qb().
+		Select(dest.columns()...).
+		From(userTableName).
+		Where(sq.Eq{"id": userID}).
+		Where(userNotDeletedCondition)
+
+	err := u.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (u *userQuery) GetUserIncludingDeleted(ctx context.Context, userID int64) (UserTable, error) {
 	var dest UserTable
 	query := qb().
 		Select(dest.columns()...).
@@ -59,7 +120,6 @@ func (u *userQuery) CreateUser(
 	telegramID int64,
 	role string,
 	notificationStatus string,
-	group string,
 	firstname string,
 	lastname string,
 	patronymic sql.NullString,
@@ -73,7 +133,6 @@ func (u *userQuery) CreateUser(
 			"telegram_id",
 			"role",
 			"notification_status",
-			"user_group",
 			"firstname",
 			"surname",
 			"patronymic",
@@ -84,7 +143,6 @@ func (u *userQuery) CreateUser(
 			telegramID,
 			role,
 			notificationStatus,
-			group,
 			firstname,
 			lastname,
 			patronymic,
@@ -96,21 +154,75 @@ func (u *userQuery) CreateUser(
 	return dest, err
 }
 
-func (u *userQuery) DeleteUser(ctx context.Context, userID int64) error {
+func (u *userQuery) DeleteUser(ctx context.Context, userID int64, deletedBy sql.NullInt64) error {
+	var dest int64
+	query := qb().
+		Update(userTableName).
+		Set("deleted_at", sq.Expr("now()")).
+		Set("deleted_by", deletedBy).
+		Where(sq.Eq{"id": userID}).
+		Where(userNotDeletedCondition).
+		Suffix("RETURNING id")
+	return u.storage.GetX(ctx, &dest, query)
+}
+
+func (u *userQuery) DeleteUsers(ctx context.Context, userIDs []int64, deletedBy sql.NullInt64) ([]int64, error) {
+	var dest []int64
+	query := qb().
+		Update(userTableName).
+		Set("deleted_at", sq.Expr("now()")).
+		Set("deleted_by", deletedBy).
+		Where("id = ANY(?)", pq.Array(userIDs)).
+		Where(userNotDeletedCondition).
+		Suffix("RETURNING id")
+	err := u.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (u *userQuery) RestoreUser(ctx context.Context, userID int64) error {
 	query := qb().
-		Delete(userTableName).
+		Update(userTableName).
+		Set("deleted_at", nil).
+		Set("deleted_by", nil).
 		Where(sq.Eq{"id": userID})
 	return u.storage.ExecX(ctx, query)
 }
 
-func (u *userQuery) GetUsersByIds(ctx context.Context, userIDs []int64, limit uint64, offset uint64) ([]UserTable, error) {
-	var dest []UserTable
+func (u *userQuery) PurgeUser(ctx context.Context, userID int64) error {
 	query := qb().
-		Select(UserTable{}.columns()...).
-		From(userTableName).
-		Where("id = ANY(?)", pq.Array(userIDs)).
+		Update(userTableName).
+		Set("telegram_id", 0).
+		Set("firstname", purgedUserName).
+		Set("surname", purgedUserName).
+		Set("patronymic", nil).
+		Set("mobile_phone", "").
+		Set("notification_status", purgedUserNotificationStatus).
+		Set("deleted_at", sq.Expr("COALESCE(deleted_at, now())")).
+		Set("purged_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": userID})
+	return u.storage.ExecX(ctx, query)
+}
+
+func (u *userQuery) GetUsersByIds(
+	ctx context.Context,
+	userIDs []int64,
+	includeDeleted bool,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]UserTable, error) {
+	var dest []UserTable
+	query := usersByIdsQuery(
+		qb().
+			Select(UserTable{}.columns()...).
+			From(userTableName),
+		userIDs,
+		includeDeleted,
+	)
+	query = applyOrderBy(query, orderBy, "id").
 		Limit(limit).
 		Offset(offset)
+
 	err := u.storage.SelectX(ctx, &dest, query)
 	if err != nil {
 		return nil, err
@@ -119,41 +231,187 @@ func (u *userQuery) GetUsersByIds(ctx context.Context, userIDs []int64, limit ui
 	return dest, err
 }
 
+func (u *userQuery) GetUsersByIdsAfter(
+	ctx context.Context,
+	userIDs []int64,
+	includeDeleted bool,
+	keyset Keyset,
+	limit uint64,
+) ([]UserTable, error) {
+	var dest []UserTable
+	query := usersByIdsQuery(
+		qb().
+			Select(UserTable{}.columns()...).
+			From(userTableName),
+		userIDs,
+		includeDeleted,
+	)
+	query = applyKeyset(query, keyset, userTableName).
+		Limit(limit)
+
+	err := u.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (u *userQuery) CountUsersByIds(ctx context.Context, userIDs []int64, includeDeleted bool) (int64, error) {
+	var dest int64
+	query := usersByIdsQuery(
+		qb().
+			Select("count(*)").
+			From(userTableName),
+		userIDs,
+		includeDeleted,
+	)
+
+	err := u.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func usersByIdsQuery(query sq.SelectBuilder, userIDs []int64, includeDeleted bool) sq.SelectBuilder {
+	query = query.Where("id = ANY(?)", pq.Array(userIDs))
+	if !includeDeleted {
+		query = query.Where(userNotDeletedCondition)
+	}
+	return query
+}
+
+func (u *userQuery) GetUsersByTelegramIds(ctx context.Context, telegramIDs []int64) ([]UserTable, error) {
+	var dest []UserTable
+	query := qb().
+		Select(UserTable{}.columns()...).
+		From(userTableName).
+		Where("telegram_id = ANY(?)", pq.Array(telegramIDs)).
+		Where(userNotDeletedCondition)
+
+	err := u.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
 func (u *userQuery) ChangeUser(ctx context.Context, user UserTable, fields ...string) (UserTable, error) {
 	var dest UserTable
+	query := changeUsersQuery(user, fields).
+		Where(sq.Eq{"id": user.Id})
+
+	err := u.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (u *userQuery) ChangeUsers(
+	ctx context.Context,
+	user UserTable,
+	userIDs []int64,
+	fields ...string,
+) ([]UserTable, error) {
+	var dest []UserTable
+	query := changeUsersQuery(user, fields).
+		Where("id = ANY(?)", pq.Array(userIDs))
+
+	err := u.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func changeUsersQuery(user UserTable, fields []string) sq.UpdateBuilder {
 	userMap := user.toMap()
 
+	query := qb().Update(userTableName)
+	for _, field := range fields {
+		query = query.Set(field, userMap[field])
+	}
+	// deleted users are read only until they are restored
+	return query.
+		Where(userNotDeletedCondition).
+		Suffix("RETURNING " + strings.Join(UserTable{}.columns(), ", "))
+}
+
+func (u *userQuery) ChangeEachUser(ctx context.Context, users []UserTable, fields ...string) ([]UserTable, error) {
+	var dest []UserTable
+
+	// values are passed as a json array of rows of users to get column types from the table
+	rows := make([]map[string]interface{}, 0, len(users))
+	for _, user := range users {
+		userMap := user.toMap()
+		row := map[string]interface{}{"id": user.Id}
+		for _, field := range fields {
+			value := userMap[field]
+			if valuer, ok := value.(driver.Valuer); ok {
+				var err error
+				if value, err = valuer.Value(); err != nil {
+					return nil, err
+				}
+			}
+			row[field] = value
+		}
+		rows = append(rows, row)
+	}
+	values, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+
 	query := qb().
 		Update(userTableName).
-		Where(sq.Eq{"id": user.Id})
-
+		PrefixExpr(sq.Expr("WITH v AS (SELECT * FROM json_populate_recordset(NULL::"+userTableName+", ?::json))", string(values))).
+		Where("id IN (SELECT id FROM v)").
+		Where(userNotDeletedCondition)
 	for _, field := range fields {
-		query = query.Set(field, userMap[field])
+		query = query.Set(field, sq.Select("v."+field).From("v").Where("v.id = "+userTableName+".id"))
 	}
-	query = query.Suffix("RETURNING *")
+	query = query.Suffix("RETURNING " + strings.Join(UserTable{}.columns(), ", "))
 
-	err := u.storage.GetX(ctx, &dest, query)
+	err = u.storage.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (u *userQuery) CreateUsers(ctx context.Context, users []UserTable) ([]UserTable, error) {
+	var dest []UserTable
+	query := qb().
+		Insert(userTableName).
+		Columns(
+			"telegram_id",
+			"role",
+			"notification_status",
+			"firstname",
+			"surname",
+			"patronymic",
+			"mobile_phone",
+			"status",
+		)
+	for _, user := range users {
+		query = query.Values(
+			user.TelegramId,
+			user.Role,
+			user.NotificationStatus,
+			user.Firstname,
+			user.Surname,
+			user.Patronymic,
+			user.MobilePhone,
+			user.Status,
+		)
+	}
+	query = query.Suffix("RETURNING " + strings.Join(UserTable{}.columns(), ", "))
+
+	err := u.storage.SelectX(ctx, &dest, query)
 	return dest, err
 }
 
 func (u *userQuery) GetUserByFilter(
 	ctx context.Context,
-	user UserTable,
+	filter UserFilter,
+	orderBy []OrderBy,
 	limit uint64,
 	offset uint64,
-	fields ...string,
 ) ([]UserTable, error) {
 	var dest []UserTable
-	userMap := user.toMap()
 
-	query := qb().
-		Select(UserTable{}.columns()...).
-		From(userTableName)
-
-	for _, field := range fields {
-		query = query.Where(sq.Eq{field: userMap[field]})
+	query := filter.apply(
+		qb().
+			Select(UserTable{}.columns()...).
+			From(userTableName),
+	)
+	if len(orderBy) == 0 {
+		query = filter.orderByRelevance(query)
 	}
-	query = query.
+	query = applyOrderBy(query, orderBy, "id").
 		Limit(limit).
 		Offset(offset)
 
@@ -161,13 +419,57 @@ func (u *userQuery) GetUserByFilter(
 	return dest, err
 }
 
-func (q *userQuery) GetGroups(ctx context.Context) ([]string, error) {
-	var dest []string
+func (u *userQuery) CountUsersByFilter(ctx context.Context, filter UserFilter) (int64, error) {
+	var dest int64
+	query := filter.apply(
+		qb().
+			Select("count(*)").
+			From(userTableName),
+	)
 
-	query := qb().
-		Select("DISTINCT user_group").
-		From(userTableName)
+	err := u.storage.GetX(ctx, &dest, query)
+	return dest, err
+}
 
-	err := q.storage.SelectX(ctx, &dest, query)
+func (u *userQuery) GetUsersByFilterAfter(
+	ctx context.Context,
+	filter UserFilter,
+	keyset Keyset,
+	limit uint64,
+) ([]UserTable, error) {
+	var dest []UserTable
+
+	query := filter.apply(
+		qb().
+			Select(UserTable{}.columns()...).
+			From(userTableName),
+	)
+	query = applyKeyset(query, keyset, userTableName).
+		Limit(limit)
+
+	err := u.storage.SelectX(ctx, &dest, query)
 	return dest, err
 }
+
+func (u *userQuery) GetDuplicates(ctx context.Context) ([]UserDuplicateTable, error) {
+	var dest []UserDuplicateTable
+	for _, field := range userUniqueFields {
+		var duplicates []UserDuplicateTable
+		query := qb().
+			Select(
+				"'"+field+"' AS field",
+				field+"::text AS value",
+				"array_agg(id ORDER BY id) AS user_ids",
+			).
+			From(userTableName).
+			Where(userNotDeletedCondition).
+			GroupBy(field).
+			Having("count(*) > 1").
+			OrderBy(field)
+		if err := u.storage.SelectX(ctx, &duplicates, query); err != nil {
+			return nil, err
+		}
+		dest = append(dest, duplicates...)
+	}
+	return dest, nil
+}
@@ -3,10 +3,28 @@ package dao
 import (
 	"database/sql"
 	"github.com/elgris/stom"
+	"github.com/lib/pq"
 )
 
 const (
 	userTableName = "users"
+
+	// userGroupsColumn selects names of the groups the user is a member of
+	userGroupsColumn = "ARRAY(SELECT g.name FROM " + userGroupMembershipTableName + " m JOIN " + groupTableName +
+		" g ON g.id = m.group_id WHERE m.user_id = " + userTableName + ".id ORDER BY g.name) AS groups"
+
+	// purgedUserName replaces names of purged users
+	purgedUserName = "deleted"
+	// purgedUserNotificationStatus stops notifications for purged users
+	purgedUserNotificationStatus = "DISABLE"
+
+	// userNotDeletedCondition excludes soft deleted users
+	userNotDeletedCondition = userTableName + ".deleted_at IS NULL"
+	// userSearchExpression is fio and mobile phone of the user in lower case, it's indexed for free text search
+	userSearchExpression = "lower(firstname || ' ' || surname || ' ' || coalesce(patronymic, '') || ' ' || mobile_phone)"
+
+	// memberNotDeletedCondition excludes memberships of soft deleted users, memberships are aliased as m
+	memberNotDeletedCondition = "m.user_id IN (SELECT id FROM " + userTableName + " WHERE deleted_at IS NULL)"
 )
 
 type UserTable struct {
@@ -14,18 +32,45 @@ type UserTable struct {
 	TelegramId         int64          `db:"telegram_id"`
 	Role               string         `db:"role"`
 	NotificationStatus string         `db:"notification_status"`
-	Group              string         `db:"user_group"`
 	Firstname          string         `db:"firstname"`
 	Surname            string         `db:"surname"`
 	Patronymic         sql.NullString `db:"patronymic"`
 	MobilePhone        string         `db:"mobile_phone"`
 	Status             string         `db:"status"`
+	DeletedAt          sql.NullTime   `db:"deleted_at"`
+	DeletedBy          sql.NullInt64  `db:"deleted_by"`
+	// PurgedAt is set when personal data of the user is erased
+	PurgedAt sql.NullTime `db:"purged_at"`
+
+	// Groups is not a column of users, it's collected from memberships
+	Groups pq.StringArray `db:"groups"`
 }
 
 var userTableStom = stom.MustNewStom(UserTable{})
 
+// userUniqueFields are columns unique among not deleted users
+var userUniqueFields = []string{"telegram_id", "mobile_phone"}
+
+// UserDuplicateTable is a value of a unique field shared by several not deleted users
+type UserDuplicateTable struct {
+	Field   string        `db:"field"`
+	Value   string        `db:"value"`
+	UserIDs pq.Int64Array `db:"user_ids"`
+}
+
 func (u UserTable) columns() []string {
-	return userTableStom.TagValues()
+	columns := userTableStom.TagValues()
+	for idx := range columns {
+		if columns[idx] == "groups" {
+			columns[idx] = userGroupsColumn
+		}
+	}
+	return columns
+}
+
+// Keyset returns the position of the user in a list ordered by orderBy
+func (u UserTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(u.toMap(), orderBy)
 }
 
 func (u UserTable) toMap() map[string]interface{} {
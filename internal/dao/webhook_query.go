@@ -0,0 +1,337 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"strings"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/storage"
+	"time"
+)
+
+type WebhookQuery interface {
+	CreateWebhook(
+		ctx context.Context,
+		url string,
+		eventTypes []string,
+		secret string,
+		createdAt time.Time,
+	) (WebhookTable, error)
+	GetWebhook(ctx context.Context, webhookID int64) (WebhookTable, error)
+	GetWebhooksByIDs(ctx context.Context, webhookIDs []int64) ([]WebhookTable, error)
+	GetWebhooks(ctx context.Context, orderBy []OrderBy, limit uint64, offset uint64) ([]WebhookTable, error)
+	// GetWebhooksAfter returns webhooks after the position of the keyset in its order
+	GetWebhooksAfter(ctx context.Context, keyset Keyset, limit uint64) ([]WebhookTable, error)
+	CountWebhooks(ctx context.Context) (int64, error)
+	ChangeWebhook(ctx context.Context, webhook WebhookTable, fields ...string) (WebhookTable, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+
+	// EnqueueDeliveries queues the event to active webhooks subscribed to its type
+	EnqueueDeliveries(ctx context.Context, eventType string, payload types.JSONText, createdAt time.Time) error
+	// ClaimDueDeliveries takes up to limit queued deliveries due at now and postpones them till leaseUntil,
+	// so other instances don't send them while they are being sent
+	ClaimDueDeliveries(
+		ctx context.Context,
+		now time.Time,
+		leaseUntil time.Time,
+		limit uint64,
+	) ([]WebhookDeliveryTable, error)
+	GetDelivery(ctx context.Context, deliveryID int64) (WebhookDeliveryTable, error)
+	// GetDeliveries returns deliveries from newest to oldest
+	GetDeliveries(
+		ctx context.Context,
+		filter WebhookDeliveryFilter,
+		limit uint64,
+		offset uint64,
+	) ([]WebhookDeliveryTable, error)
+	// GetDeliveriesAfter returns deliveries after the position of the keyset in its order
+	GetDeliveriesAfter(
+		ctx context.Context,
+		filter WebhookDeliveryFilter,
+		keyset Keyset,
+		limit uint64,
+	) ([]WebhookDeliveryTable, error)
+	CountDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (int64, error)
+	ChangeDelivery(ctx context.Context, delivery WebhookDeliveryTable, fields ...string) (WebhookDeliveryTable, error)
+	// RedactDeliveryPayloads hides values of the fields in data of delivery payloads about the user
+	RedactDeliveryPayloads(ctx context.Context, userID int64, fields []string) error
+}
+
+type webhookQuery struct {
+	db storage.Storage
+}
+
+func newWebhookQuery(db storage.Storage) WebhookQuery {
+	return &webhookQuery{
+		db: db,
+	}
+}
+
+func (w *webhookQuery) CreateWebhook(
+	ctx context.Context,
+	url string,
+	eventTypes []string,
+	secret string,
+	createdAt time.Time,
+) (WebhookTable, error) {
+	var dest WebhookTable
+	query := qb().
+		Insert(webhookTableName).
+		Columns(
+			"url",
+			"event_types",
+			"secret",
+			"created_at",
+		).
+		Values(
+			url,
+			pq.StringArray(eventTypes),
+			secret,
+			createdAt,
+		).
+		Suffix("RETURNING " + strings.Join(dest.columns(), ", "))
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetWebhook(ctx context.Context, webhookID int64) (WebhookTable, error) {
+	var dest WebhookTable
+	query := qb().
+		Select(dest.columns()...).
+		From(webhookTableName).
+		Where(sq.Eq{"id": webhookID})
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetWebhooksByIDs(ctx context.Context, webhookIDs []int64) ([]WebhookTable, error) {
+	var dest []WebhookTable
+	query := qb().
+		Select(WebhookTable{}.columns()...).
+		From(webhookTableName).
+		Where(sq.Eq{"id": webhookIDs})
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetWebhooks(
+	ctx context.Context,
+	orderBy []OrderBy,
+	limit uint64,
+	offset uint64,
+) ([]WebhookTable, error) {
+	var dest []WebhookTable
+	query := qb().
+		Select(WebhookTable{}.columns()...).
+		From(webhookTableName)
+	query = applyOrderBy(query, orderBy, "id").
+		Limit(limit).
+		Offset(offset)
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetWebhooksAfter(ctx context.Context, keyset Keyset, limit uint64) ([]WebhookTable, error) {
+	var dest []WebhookTable
+	query := qb().
+		Select(WebhookTable{}.columns()...).
+		From(webhookTableName)
+	query = applyKeyset(query, keyset, webhookTableName).
+		Limit(limit)
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) CountWebhooks(ctx context.Context) (int64, error) {
+	var dest int64
+	query := qb().
+		Select("count(*)").
+		From(webhookTableName)
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) ChangeWebhook(ctx context.Context, webhook WebhookTable, fields ...string) (WebhookTable, error) {
+	var dest WebhookTable
+	webhookMap := webhook.toMap()
+
+	query := qb().
+		Update(webhookTableName).
+		Where(sq.Eq{"id": webhook.ID})
+
+	for _, field := range fields {
+		query = query.Set(field, webhookMap[field])
+	}
+	query = query.Suffix("RETURNING " + strings.Join(dest.columns(), ", "))
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	query := qb().
+		Delete(webhookTableName).
+		Where(sq.Eq{"id": webhookID})
+	return w.db.ExecX(ctx, query)
+}
+
+func (w *webhookQuery) EnqueueDeliveries(
+	ctx context.Context,
+	eventType string,
+	payload types.JSONText,
+	createdAt time.Time,
+) error {
+	query := qb().
+		Insert(webhookDeliveryTableName).
+		Columns(
+			"webhook_id",
+			"event_type",
+			"payload",
+			"status",
+			"next_attempt_at",
+			"created_at",
+		).
+		Select(
+			sq.Select().
+				Column("id").
+				Column("?", eventType).
+				Column("?::jsonb", payload).
+				Column("?", desc.WebhookDeliveryStatus_QUEUED.String()).
+				Column("?::timestamp", createdAt).
+				Column("?::timestamp", createdAt).
+				From(webhookTableName).
+				Where(sq.Eq{"active": true}).
+				Where("? = ANY(event_types)", eventType),
+		)
+	return w.db.ExecX(ctx, query)
+}
+
+func (w *webhookQuery) ClaimDueDeliveries(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit uint64,
+) ([]WebhookDeliveryTable, error) {
+	var dest []WebhookDeliveryTable
+	due := sq.Select("id").
+		From(webhookDeliveryTableName).
+		Where(sq.Eq{"status": desc.WebhookDeliveryStatus_QUEUED.String()}).
+		Where(sq.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+	query := qb().
+		Update(webhookDeliveryTableName).
+		Set("next_attempt_at", leaseUntil).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING " + strings.Join(WebhookDeliveryTable{}.columns(), ", "))
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetDelivery(ctx context.Context, deliveryID int64) (WebhookDeliveryTable, error) {
+	var dest WebhookDeliveryTable
+	query := qb().
+		Select(dest.columns()...).
+		From(webhookDeliveryTableName).
+		Where(sq.Eq{"id": deliveryID})
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetDeliveries(
+	ctx context.Context,
+	filter WebhookDeliveryFilter,
+	limit uint64,
+	offset uint64,
+) ([]WebhookDeliveryTable, error) {
+	var dest []WebhookDeliveryTable
+	query := filter.apply(
+		qb().
+			Select(WebhookDeliveryTable{}.columns()...).
+			From(webhookDeliveryTableName),
+	).
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset(offset)
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) GetDeliveriesAfter(
+	ctx context.Context,
+	filter WebhookDeliveryFilter,
+	keyset Keyset,
+	limit uint64,
+) ([]WebhookDeliveryTable, error) {
+	var dest []WebhookDeliveryTable
+	query := filter.apply(
+		qb().
+			Select(WebhookDeliveryTable{}.columns()...).
+			From(webhookDeliveryTableName),
+	)
+	query = applyKeyset(query, keyset, webhookDeliveryTableName).
+		Limit(limit)
+
+	err := w.db.SelectX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) CountDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (int64, error) {
+	var dest int64
+	query := filter.apply(
+		qb().
+			Select("count(*)").
+			From(webhookDeliveryTableName),
+	)
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) ChangeDelivery(
+	ctx context.Context,
+	delivery WebhookDeliveryTable,
+	fields ...string,
+) (WebhookDeliveryTable, error) {
+	var dest WebhookDeliveryTable
+	deliveryMap := delivery.toMap()
+
+	query := qb().
+		Update(webhookDeliveryTableName).
+		Where(sq.Eq{"id": delivery.ID})
+
+	for _, field := range fields {
+		query = query.Set(field, deliveryMap[field])
+	}
+	query = query.Suffix("RETURNING " + strings.Join(dest.columns(), ", "))
+
+	err := w.db.GetX(ctx, &dest, query)
+	return dest, err
+}
+
+func (w *webhookQuery) RedactDeliveryPayloads(ctx context.Context, userID int64, fields []string) error {
+	query := qb().
+		Update(webhookDeliveryTableName).
+		Set("payload", sq.Expr(
+			"jsonb_set(payload, '{data}', payload->'data' || "+
+				"(SELECT COALESCE(jsonb_object_agg(key, to_jsonb(?::text)), '{}'::jsonb) "+
+				"FROM jsonb_object_keys(payload->'data') key WHERE key = ANY(?)))",
+			RedactedValue,
+			pq.Array(fields),
+		)).
+		Where("payload @> ?::jsonb", fmt.Sprintf(`{"data": {"user_id": %d}}`, userID))
+	return w.db.ExecX(ctx, query)
+}
@@ -0,0 +1,98 @@
+package dao
+
+import (
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/elgris/stom"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	webhookTableName         = "webhooks"
+	webhookDeliveryTableName = "webhook_deliveries"
+)
+
+type WebhookTable struct {
+	ID         int64          `db:"id"`
+	URL        string         `db:"url"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Secret     string         `db:"secret"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+var webhookTableStom = stom.MustNewStom(WebhookTable{})
+
+func (w WebhookTable) columns() []string {
+	return webhookTableStom.TagValues()
+}
+
+// Keyset returns the position of the webhook in a list ordered by orderBy
+func (w WebhookTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(w.toMap(), orderBy)
+}
+
+func (w WebhookTable) toMap() map[string]interface{} {
+	m, err := webhookTableStom.ToMap(w)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// WebhookDeliveryTable is an event queued to be sent to a webhook together with the result of the last attempt
+type WebhookDeliveryTable struct {
+	ID            int64          `db:"id"`
+	WebhookID     int64          `db:"webhook_id"`
+	EventType     string         `db:"event_type"`
+	Payload       types.JSONText `db:"payload"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LastAttemptAt sql.NullTime   `db:"last_attempt_at"`
+	// LastStatusCode is the http status of the last attempt, it's null if no response was received
+	LastStatusCode sql.NullInt64  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+var webhookDeliveryTableStom = stom.MustNewStom(WebhookDeliveryTable{})
+
+func (w WebhookDeliveryTable) columns() []string {
+	return webhookDeliveryTableStom.TagValues()
+}
+
+// Keyset returns the position of the delivery in a list ordered by orderBy
+func (w WebhookDeliveryTable) Keyset(orderBy OrderBy) Keyset {
+	return newKeyset(w.toMap(), orderBy)
+}
+
+func (w WebhookDeliveryTable) toMap() map[string]interface{} {
+	m, err := webhookDeliveryTableStom.ToMap(w)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// WebhookDeliveryFilter describes conditions for GetWebhookDeliveries, zero values don't limit the result
+type WebhookDeliveryFilter struct {
+	WebhookID sql.NullInt64
+	Statuses  []string
+	EventType string
+}
+
+func (f WebhookDeliveryFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	if f.WebhookID.Valid {
+		query = query.Where(sq.Eq{"webhook_id": f.WebhookID.Int64})
+	}
+	if len(f.Statuses) > 0 {
+		query = query.Where(sq.Eq{"status": f.Statuses})
+	}
+	if f.EventType != "" {
+		query = query.Where(sq.Eq{"event_type": f.EventType})
+	}
+	return query
+}
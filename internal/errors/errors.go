@@ -3,7 +3,10 @@ package errors
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"regexp"
 
+	"github.com/lib/pq"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,16 +21,55 @@ func wrapSQLErrorToNetwork(err error) *NetworkError {
 		err: err,
 	}
 
+	// errors returned by handlers through a transaction already have a code
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		n.code = grpcErr.GRPCStatus().Code()
+		n.err = errors.New(grpcErr.GRPCStatus().Message())
+		return n
+	}
+
 	if errors.Is(err, sql.ErrNoRows) {
 		n.code = codes.NotFound
 	}
 
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolation:
+			n.code = codes.AlreadyExists
+			n.err = errors.New(conflictMessage(pqErr))
+		case foreignKeyViolation:
+			n.code = codes.FailedPrecondition
+			if pqErr.Detail != "" {
+				n.err = errors.New(pqErr.Detail)
+			}
+		}
+	}
+
 	if n.code == 0 && n.err != nil {
 		n.code = codes.Internal
 	}
 	return n
 }
 
+const (
+	uniqueViolation     pq.ErrorCode = "23505"
+	foreignKeyViolation pq.ErrorCode = "23503"
+)
+
+// conflictDetail matches the detail of a unique violation, e.g. Key (telegram_id)=(42) already exists.
+var conflictDetail = regexp.MustCompile(`^Key \((.+?)\)=\((.*)\) already exists`)
+
+// conflictMessage names the conflicting field and its value
+func conflictMessage(pqErr *pq.Error) string {
+	match := conflictDetail.FindStringSubmatch(pqErr.Detail)
+	if match == nil {
+		return fmt.Sprintf("violates unique constraint %q", pqErr.Constraint)
+	}
+	return fmt.Sprintf("%s %s already exists", match[1], match[2])
+}
+
 type NetworkError struct {
 	err  error
 	code codes.Code
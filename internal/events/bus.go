@@ -0,0 +1,183 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"telegram-notification-api/internal/dao"
+)
+
+const (
+	// channel is the postgres channel events are sent to
+	channel = "delivery_events"
+	// subscriptionBuffer is the number of events a subscriber can fall behind before it's dropped
+	subscriptionBuffer = 256
+	// maxErrorLength keeps errors of events far below the 8000 bytes limit of notify payloads
+	maxErrorLength = 512
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+// Event is a change of a delivery of a notification or the end of sending of the notification
+type Event struct {
+	NotificationID int64 `json:"n"`
+	// UserID, Status and Error describe the delivery, they are empty for the final event
+	UserID int64  `json:"u,omitempty"`
+	Status string `json:"s,omitempty"`
+	Error  string `json:"e,omitempty"`
+	// Completed marks the final event, it's sent once all messages of the notification are sent or skipped
+	Completed bool      `json:"c,omitempty"`
+	At        time.Time `json:"t"`
+}
+
+// Bus delivers events to subscribers of all instances.
+// Events are published with postgres NOTIFY and every instance passes events it listens to its subscribers.
+type Bus struct {
+	dao dao.DAO
+	log *slog.Logger
+
+	mu            sync.Mutex
+	subscriptions map[int64]map[*Subscription]struct{}
+}
+
+// Subscription receives events of a notification, its channel is closed if events are lost
+type Subscription struct {
+	bus            *Bus
+	notificationID int64
+	events         chan Event
+}
+
+func NewBus(d dao.DAO, log *slog.Logger) *Bus {
+	return &Bus{
+		dao:           d,
+		log:           log,
+		subscriptions: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends events to subscribers, watching is best effort so failures are only logged
+func (b *Bus) Publish(ctx context.Context, events ...Event) {
+	payloads := make([]string, 0, len(events))
+	for _, event := range events {
+		if len(event.Error) > maxErrorLength {
+			event.Error = event.Error[:maxErrorLength]
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			b.log.Error("can't encode delivery event", slog.Any("err", err))
+			return
+		}
+		payloads = append(payloads, string(payload))
+	}
+	if err := b.dao.NewEventQuery().Notify(ctx, channel, payloads); err != nil {
+		b.log.Error("can't publish delivery events", slog.Any("err", err))
+	}
+}
+
+// Subscribe returns a subscription to events of the notification, it must be closed
+func (b *Bus) Subscribe(notificationID int64) *Subscription {
+	s := &Subscription{
+		bus:            b,
+		notificationID: notificationID,
+		events:         make(chan Event, subscriptionBuffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscriptions[notificationID] == nil {
+		b.subscriptions[notificationID] = make(map[*Subscription]struct{})
+	}
+	b.subscriptions[notificationID][s] = struct{}{}
+	return s
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
+
+// Listen receives events published by all instances and passes them to subscribers until ctx is done
+func (b *Bus) Listen(ctx context.Context, connectionString string) error {
+	listener := pq.NewListener(
+		connectionString,
+		minReconnectInterval,
+		maxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				b.log.Error("delivery events listener failed", slog.Any("err", err))
+			}
+		},
+	)
+	defer listener.Close()
+	if err := listener.Listen(channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// the connection is restored, events sent meanwhile are lost
+				b.dropAll()
+				continue
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				b.log.Error("can't decode delivery event", slog.Any("err", err))
+				continue
+			}
+			b.dispatch(event)
+		}
+	}
+}
+
+func (b *Bus) dispatch(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscriptions[event.NotificationID] {
+		select {
+		case s.events <- event:
+		default:
+			// the subscriber is too slow, it has to subscribe again
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Bus) dropAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subscriptions := range b.subscriptions {
+		for s := range subscriptions {
+			b.remove(s)
+		}
+	}
+}
+
+// remove closes the subscription if it's not closed yet, b.mu must be held
+func (b *Bus) remove(s *Subscription) {
+	subscriptions, ok := b.subscriptions[s.notificationID]
+	if !ok {
+		return
+	}
+	if _, ok = subscriptions[s]; !ok {
+		return
+	}
+	delete(subscriptions, s)
+	if len(subscriptions) == 0 {
+		delete(b.subscriptions, s.notificationID)
+	}
+	close(s.events)
+}
@@ -0,0 +1,64 @@
+package links
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"telegram-notification-api/internal/dao"
+)
+
+const redirectPath = "/l/"
+
+// previewAgents are user agents of services that fetch links to build previews, their requests aren't clicks
+var previewAgents = []string{"TelegramBot"}
+
+// NewHandler serves redirect links of the tracker and records clicks of receivers
+func NewHandler(t *Tracker, d dao.DAO, log *slog.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirectPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		linkID, userID, err := t.parseCode(strings.TrimPrefix(r.URL.Path, redirectPath))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		linkQuery := d.NewLinkQuery()
+		link, err := linkQuery.GetLink(r.Context(), linkID)
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Error("can't get tracked link", slog.Int64("link_id", linkID), slog.Any("err", err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if r.Method == http.MethodGet && !isPreview(r) {
+			// the receiver is redirected even if the click isn't recorded
+			if err = linkQuery.AddClick(r.Context(), linkID, userID, time.Now()); err != nil {
+				log.Error("can't record link click", slog.Int64("link_id", linkID), slog.Any("err", err))
+			}
+		}
+		http.Redirect(w, r, link.URL, http.StatusFound)
+	})
+	return mux
+}
+
+func isPreview(r *http.Request) bool {
+	userAgent := r.UserAgent()
+	for _, agent := range previewAgents {
+		if strings.Contains(userAgent, agent) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package links
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"telegram-notification-api/internal/config"
+)
+
+// signatureSize is the number of bytes of the hmac kept in a code, it's enough against guessing
+// and keeps links short
+const signatureSize = 6
+
+var ErrInvalidCode = errors.New("invalid link code")
+
+var (
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+	// urlTrailer is punctuation that ends a sentence rather than a url
+	urlTrailer = ".,;:!?)]'"
+)
+
+// Tracker rewrites urls of messages to redirect links of the receiver.
+// A code of a redirect link holds the id of the tracked link and the id of the receiver,
+// it's signed so clicks can't be forged for other receivers.
+type Tracker struct {
+	enabled bool
+	baseURL string
+	key     []byte
+}
+
+func NewTracker(cfg config.Links) (*Tracker, error) {
+	const op = "links.NewTracker"
+
+	if !cfg.Enabled {
+		return &Tracker{}, nil
+	}
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("%s: base_url is required", op)
+	}
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("%s: secret is required", op)
+	}
+	return &Tracker{
+		enabled: true,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		key:     []byte(cfg.Secret),
+	}, nil
+}
+
+func (t *Tracker) Enabled() bool {
+	return t.enabled
+}
+
+// URLs returns distinct urls of the message in order of appearance
+func (t *Tracker) URLs(message string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, match := range urlPattern.FindAllString(message, -1) {
+		url := strings.TrimRight(match, urlTrailer)
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// Rewrite replaces urls of the message that have tracked link ids with redirect links of the receiver
+func (t *Tracker) Rewrite(message string, linkIDs map[string]int64, userID int64) string {
+	return urlPattern.ReplaceAllStringFunc(message, func(match string) string {
+		url := strings.TrimRight(match, urlTrailer)
+		linkID, ok := linkIDs[url]
+		if !ok {
+			return match
+		}
+		return t.baseURL + redirectPath + t.code(linkID, userID) + match[len(url):]
+	})
+}
+
+func (t *Tracker) code(linkID int64, userID int64) string {
+	payload := binary.AppendUvarint(nil, uint64(linkID))
+	payload = binary.AppendUvarint(payload, uint64(userID))
+	return base64.RawURLEncoding.EncodeToString(append(payload, t.sign(payload)...))
+}
+
+// parseCode returns the tracked link id and the receiver id of the code
+func (t *Tracker) parseCode(code string) (int64, int64, error) {
+	raw, err := base64.RawURLEncoding.Strict().DecodeString(code)
+	if err != nil || len(raw) <= signatureSize {
+		return 0, 0, ErrInvalidCode
+	}
+	payload, signature := raw[:len(raw)-signatureSize], raw[len(raw)-signatureSize:]
+	if !hmac.Equal(signature, t.sign(payload)) {
+		return 0, 0, ErrInvalidCode
+	}
+
+	linkID, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return 0, 0, ErrInvalidCode
+	}
+	userID, m := binary.Uvarint(payload[n:])
+	if m <= 0 || n+m != len(payload) {
+		return 0, 0, ErrInvalidCode
+	}
+	return int64(linkID), int64(userID), nil
+}
+
+func (t *Tracker) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:signatureSize]
+}
@@ -0,0 +1,163 @@
+package links
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+)
+
+type click struct {
+	linkID int64
+	userID int64
+}
+
+// linkDAO serves tracked links from memory and records clicks
+type linkDAO struct {
+	dao.DAO
+	links  map[int64]string
+	clicks []click
+}
+
+func (d *linkDAO) NewLinkQuery() dao.LinkQuery {
+	return linkQuery{d: d}
+}
+
+type linkQuery struct {
+	dao.LinkQuery
+	d *linkDAO
+}
+
+func (q linkQuery) GetLink(_ context.Context, linkID int64) (dao.LinkTable, error) {
+	url, ok := q.d.links[linkID]
+	if !ok {
+		return dao.LinkTable{}, sql.ErrNoRows
+	}
+	return dao.LinkTable{ID: linkID, URL: url}, nil
+}
+
+func (q linkQuery) AddClick(_ context.Context, linkID int64, userID int64, _ time.Time) error {
+	q.d.clicks = append(q.d.clicks, click{linkID: linkID, userID: userID})
+	return nil
+}
+
+const baseURL = "https://t.example.com"
+
+func TestTrackerRewrite(t *testing.T) {
+	tracker, err := NewTracker(config.Links{Enabled: true, BaseURL: baseURL + "/", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := "see https://example.com/a, (https://example.com/b) and https://example.com/a!"
+	urls := tracker.URLs(message)
+	if want := []string{"https://example.com/a", "https://example.com/b"}; !reflect.DeepEqual(urls, want) {
+		t.Fatalf("URLs() = %v, want %v", urls, want)
+	}
+
+	// only the first url is tracked, the second is left as is
+	got := tracker.Rewrite(message, map[string]int64{"https://example.com/a": 1}, 7)
+	link := baseURL + redirectPath + tracker.code(1, 7)
+	want := "see " + link + ", (https://example.com/b) and " + link + "!"
+	if got != want {
+		t.Errorf("Rewrite() = %q, want %q", got, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tracker, err := NewTracker(config.Links{Enabled: true, BaseURL: baseURL, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewTracker(config.Links{Enabled: true, BaseURL: baseURL, Secret: "other secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := tracker.code(1, 7)
+	raw, _ := base64.RawURLEncoding.DecodeString(code)
+	tampered := append([]byte(nil), raw...)
+	// the receiver id is the second varint of the payload
+	tampered[1]++
+
+	// a payload with a trailing byte signed with the right key, only its layout is wrong
+	trailing := binary.AppendUvarint(nil, 1)
+	trailing = binary.AppendUvarint(trailing, 7)
+	trailing = append(trailing, 0)
+	trailing = append(trailing, tracker.sign(trailing)...)
+
+	tests := []struct {
+		name         string
+		method       string
+		code         string
+		userAgent    string
+		wantStatus   int
+		wantLocation string
+		wantClicks   []click
+	}{
+		{
+			name:         "click",
+			code:         code,
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://example.com/a",
+			wantClicks:   []click{{linkID: 1, userID: 7}},
+		},
+		{
+			name:         "link preview",
+			code:         code,
+			userAgent:    "TelegramBot (like TwitterBot)",
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://example.com/a",
+		},
+		{
+			name:         "head request",
+			method:       http.MethodHead,
+			code:         code,
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://example.com/a",
+		},
+		{name: "post request", method: http.MethodPost, code: code, wantStatus: http.StatusMethodNotAllowed},
+		{name: "unknown link", code: tracker.code(2, 7), wantStatus: http.StatusNotFound},
+		{name: "code of another secret", code: other.code(1, 7), wantStatus: http.StatusNotFound},
+		{name: "forged receiver", code: base64.RawURLEncoding.EncodeToString(tampered), wantStatus: http.StatusNotFound},
+		{name: "trailing payload", code: base64.RawURLEncoding.EncodeToString(trailing), wantStatus: http.StatusNotFound},
+		{name: "truncated", code: code[:len(code)-1], wantStatus: http.StatusNotFound},
+		{name: "padded", code: code + "=", wantStatus: http.StatusNotFound},
+		{name: "empty", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &linkDAO{links: map[int64]string{1: "https://example.com/a"}}
+			handler := NewHandler(tracker, d, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, redirectPath+tt.code, nil)
+			r.Header.Set("User-Agent", tt.userAgent)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if location := w.Header().Get("Location"); location != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", location, tt.wantLocation)
+			}
+			if !reflect.DeepEqual(d.clicks, tt.wantClicks) {
+				t.Errorf("clicks = %v, want %v", d.clicks, tt.wantClicks)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"telegram-notification-api/internal/config"
+)
+
+var ErrInvalidToken = errors.New("invalid page token")
+
+// pageFields are fields of list requests that change between pages of the same list
+var pageFields = []protoreflect.Name{"page_token", "limit", "offset"}
+
+// Paginator caps page sizes and issues page tokens.
+// A token holds the cursor of the last item of a page and a fingerprint of the request,
+// it's signed so it can't be forged or used with another list, filter or order.
+type Paginator struct {
+	key         []byte
+	maxPageSize uint64
+}
+
+// Cursor is the position of an item in a list ordered by a column and then by id
+type Cursor struct {
+	AfterID int64 `json:"a"`
+	// Value is the value of the column as text, it's empty for lists ordered only by id
+	Value string `json:"v,omitempty"`
+}
+
+type token struct {
+	Cursor
+	Fingerprint []byte `json:"f"`
+}
+
+func NewPaginator(cfg config.Pagination) (*Paginator, error) {
+	const op = "pagination.NewPaginator"
+
+	if cfg.TokenSecret == "" {
+		return nil, fmt.Errorf("%s: token_secret is required", op)
+	}
+	if cfg.MaxPageSize == 0 {
+		return nil, fmt.Errorf("%s: max_page_size is required", op)
+	}
+	return &Paginator{
+		key:         []byte(cfg.TokenSecret),
+		maxPageSize: cfg.MaxPageSize,
+	}, nil
+}
+
+// PageSize returns limit capped by the maximum page size
+func (p *Paginator) PageSize(limit uint64) uint64 {
+	return min(limit, p.maxPageSize)
+}
+
+// NextToken returns a token of the page of req that follows the item at the cursor
+func (p *Paginator) NextToken(req proto.Message, cursor Cursor) (string, error) {
+	fingerprint, err := fingerprint(req)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(token{
+		Cursor:      cursor,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode(payload) + "." + encode(p.sign(payload)), nil
+}
+
+// Cursor returns the cursor of the last item of the previous page from the page token of req
+func (p *Paginator) Cursor(req proto.Message, pageToken string) (Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(pageToken, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidToken
+	}
+	payload, err := decode(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidToken
+	}
+	signature, err := decode(encodedSignature)
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return Cursor{}, ErrInvalidToken
+	}
+
+	var t token
+	if err = json.Unmarshal(payload, &t); err != nil {
+		return Cursor{}, ErrInvalidToken
+	}
+	fingerprint, err := fingerprint(req)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if !bytes.Equal(t.Fingerprint, fingerprint) {
+		return Cursor{}, fmt.Errorf("%w: it belongs to another request", ErrInvalidToken)
+	}
+	return t.Cursor, nil
+}
+
+func (p *Paginator) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// fingerprint identifies the list and the filter of req, fields of a page are ignored
+func fingerprint(req proto.Message) ([]byte, error) {
+	req = proto.Clone(req)
+	message := req.ProtoReflect()
+	for _, name := range pageFields {
+		if field := message.Descriptor().Fields().ByName(name); field != nil {
+			message.Clear(field)
+		}
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(message.Descriptor().FullName()))
+	hash.Write(data)
+	return hash.Sum(nil)[:16], nil
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
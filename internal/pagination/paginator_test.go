@@ -0,0 +1,211 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/config"
+)
+
+// items is a list ordered by created_at and then by id, it's paged like the dao pages tables
+var items = []Cursor{
+	{AfterID: 3, Value: "2024-05-01T10:00:00Z"},
+	{AfterID: 1, Value: "2024-05-01T10:00:00.5Z"},
+	{AfterID: 2, Value: "2024-05-01T10:00:00.5Z"},
+	{AfterID: 5, Value: "2024-05-02T00:00:00Z"},
+	{AfterID: 4, Value: "2024-05-03T00:00:00Z"},
+}
+
+// itemsAfter returns the page of items that follows the cursor, a zero cursor starts the list
+func itemsAfter(cursor Cursor, limit int) []Cursor {
+	start := 0
+	if cursor != (Cursor{}) {
+		for start < len(items) && items[start] != cursor {
+			start++
+		}
+		start++
+	}
+	return items[min(start, len(items)):min(start+limit, len(items))]
+}
+
+func TestPaginatorPages(t *testing.T) {
+	p, err := NewPaginator(config.Pagination{MaxPageSize: 2, TokenSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &desc.GetUsersByIdRequest{UserIds: []int64{1, 2, 3, 4, 5}, Limit: 10}
+	limit := int(p.PageSize(uint64(req.Limit)))
+	if limit != 2 {
+		t.Fatalf("PageSize(%d) = %d, want the maximum page size 2", req.Limit, limit)
+	}
+
+	var got []Cursor
+	var cursor Cursor
+	for pages := 1; ; pages++ {
+		if pages > len(items) {
+			t.Fatalf("paging doesn't stop, got %v", got)
+		}
+		page := itemsAfter(cursor, limit)
+		got = append(got, page...)
+		if len(page) < limit {
+			break
+		}
+
+		token, err := p.NextToken(req, page[len(page)-1])
+		if err != nil {
+			t.Fatalf("NextToken() error = %v", err)
+		}
+		// the client sends the token with the same request
+		next := proto.Clone(req).(*desc.GetUsersByIdRequest)
+		next.PageToken = token
+		if cursor, err = p.Cursor(next, token); err != nil {
+			t.Fatalf("Cursor() error = %v", err)
+		}
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Errorf("pages = %v, want %v", got, items)
+	}
+}
+
+func TestPaginatorPageSize(t *testing.T) {
+	p, err := NewPaginator(config.Pagination{MaxPageSize: 100, TokenSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		limit uint64
+		want  uint64
+	}{
+		{limit: 0, want: 0},
+		{limit: 10, want: 10},
+		{limit: 100, want: 100},
+		{limit: 1000, want: 100},
+	}
+	for _, tt := range tests {
+		if got := p.PageSize(tt.limit); got != tt.want {
+			t.Errorf("PageSize(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestPaginatorCursor(t *testing.T) {
+	p, err := NewPaginator(config.Pagination{MaxPageSize: 100, TokenSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewPaginator(config.Pagination{MaxPageSize: 100, TokenSecret: "other secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &desc.GetUsersByIdRequest{UserIds: []int64{1, 2, 3}, Limit: 2}
+	cursor := Cursor{AfterID: 42, Value: "2024-05-01T10:00:00.123456Z"}
+	token, err := p.NextToken(req, cursor)
+	if err != nil {
+		t.Fatalf("NextToken() error = %v", err)
+	}
+	idToken, err := p.NextToken(req, Cursor{AfterID: 7})
+	if err != nil {
+		t.Fatalf("NextToken() error = %v", err)
+	}
+	otherToken, err := other.NextToken(req, cursor)
+	if err != nil {
+		t.Fatalf("NextToken() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+	idPayload, _, _ := strings.Cut(idToken, ".")
+
+	tests := []struct {
+		name    string
+		req     *desc.GetUsersByIdRequest
+		token   string
+		want    Cursor
+		wantErr bool
+	}{
+		{
+			name:  "same request",
+			req:   req,
+			token: token,
+			want:  cursor,
+		},
+		{
+			name:  "ordered by id",
+			req:   req,
+			token: idToken,
+			want:  Cursor{AfterID: 7},
+		},
+		{
+			name:  "page fields changed",
+			req:   &desc.GetUsersByIdRequest{UserIds: []int64{1, 2, 3}, Limit: 50, PageToken: token},
+			token: token,
+			want:  cursor,
+		},
+		{
+			name:    "another filter",
+			req:     &desc.GetUsersByIdRequest{UserIds: []int64{1, 2}, Limit: 2},
+			token:   token,
+			wantErr: true,
+		},
+		{
+			name: "another order",
+			req: &desc.GetUsersByIdRequest{
+				UserIds: []int64{1, 2, 3},
+				Limit:   2,
+				OrderBy: []*desc.OrderBy{{Field: "firstname"}},
+			},
+			token:   token,
+			wantErr: true,
+		},
+		{
+			name:    "another secret",
+			req:     req,
+			token:   otherToken,
+			wantErr: true,
+		},
+		{
+			name:    "forged payload",
+			req:     req,
+			token:   idPayload + "." + signature,
+			wantErr: true,
+		},
+		{
+			name:    "no signature",
+			req:     req,
+			token:   payload,
+			wantErr: true,
+		},
+		{
+			name:    "not base64",
+			req:     req,
+			token:   "!!!." + signature,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			req:     req,
+			token:   "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Cursor(tt.req, tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Cursor() error = %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Cursor() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Cursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
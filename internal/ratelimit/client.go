@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc/peer"
+	"telegram-notification-api/internal/auth"
+)
+
+// ClientFromContext identifies the caller limits are counted for:
+// an api client, a jwt subject or, for unauthenticated calls, the peer host
+func ClientFromContext(ctx context.Context) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		switch p.Method {
+		case auth.MethodApiKey:
+			return "api_client:" + strconv.FormatInt(p.ApiClientID, 10)
+		default:
+			return string(p.Method) + ":" + p.Subject
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer:" + host
+	}
+	return "unknown"
+}
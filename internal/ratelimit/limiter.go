@@ -0,0 +1,316 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+)
+
+const (
+	// idleBucketTTL is how long buckets of clients that stopped calling are kept
+	idleBucketTTL = 10 * time.Minute
+	// defaultMethod names the default limit in Usage
+	defaultMethod = "*"
+)
+
+// reservationKey is the context key of the quota reservation of a call
+type reservationKey struct{}
+
+// reservation holds messages taken from the daily quota of a client within one call sending messages.
+// Messages are reserved before they are sent, the part that isn't sent is released when the call ends.
+type reservation struct {
+	limiter *Limiter
+	client  string
+	day     time.Time
+
+	mu       sync.Mutex
+	reserved int64
+	sent     int64
+}
+
+// bucketKey identifies a bucket, every method of a client has its own bucket, method is in lower case
+type bucketKey struct {
+	client string
+	method string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter enforces per-client request rates per method and the daily message quota.
+// Rates are tracked in memory of the replica, message usage is stored in the database.
+// Methods sending messages reserve them with ReserveMessages once receivers are known.
+type Limiter struct {
+	limits config.RateLimits
+	dao    dao.DAO
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastPrune time.Time
+}
+
+// MethodLimit is the rate limit of a method and its current state for a client
+type MethodLimit struct {
+	Method            string
+	RequestsPerSecond float64
+	Burst             int
+	Available         float64
+}
+
+type Usage struct {
+	Client            string
+	Day               time.Time
+	MessagesSent      int64
+	DailyMessageQuota int64
+	Limits            []MethodLimit
+}
+
+func NewLimiter(limits config.RateLimits, dao dao.DAO) *Limiter {
+	return &Limiter{
+		limits:  limits,
+		dao:     dao,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+func (l *Limiter) Enabled() bool {
+	return l.limits.Enabled
+}
+
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		client := ClientFromContext(ctx)
+		if err := l.allow(client, path.Base(info.FullMethod)); err != nil {
+			return nil, err
+		}
+		if l.limits.DailyMessageQuota <= 0 || !sendsMessages(req) {
+			return handler(ctx, req)
+		}
+
+		r := &reservation{limiter: l, client: client, day: today()}
+		defer r.release(ctx)
+		return handler(context.WithValue(ctx, reservationKey{}, r), req)
+	}
+}
+
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := l.allow(ClientFromContext(ss.Context()), path.Base(info.FullMethod)); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Usage reports the state of limits of the client
+func (l *Limiter) Usage(ctx context.Context, client string) (Usage, error) {
+	day := today()
+	messages, err := l.dao.NewClientUsageQuery().GetMessages(ctx, client, day)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	methods := make(map[string]struct{}, len(l.limits.Methods))
+	for method := range l.limits.Methods {
+		methods[method] = struct{}{}
+	}
+	for key := range l.buckets {
+		if key.client == client {
+			methods[key.method] = struct{}{}
+		}
+	}
+
+	limits := []MethodLimit{{
+		Method:            defaultMethod,
+		RequestsPerSecond: l.limits.Default.RequestsPerSecond,
+		Burst:             l.limits.Default.Burst,
+		Available:         float64(l.limits.Default.Burst),
+	}}
+	for method := range methods {
+		limit := l.methodLimit(method)
+		available := float64(limit.Burst)
+		if b, ok := l.buckets[bucketKey{client: client, method: method}]; ok {
+			available = b.limiter.TokensAt(now)
+		}
+		limits = append(limits, MethodLimit{
+			Method:            method,
+			RequestsPerSecond: limit.RequestsPerSecond,
+			Burst:             limit.Burst,
+			Available:         available,
+		})
+	}
+	l.mu.Unlock()
+	methodLimits := limits[1:]
+	sort.Slice(methodLimits, func(i, j int) bool {
+		return methodLimits[i].Method < methodLimits[j].Method
+	})
+
+	return Usage{
+		Client:            client,
+		Day:               day,
+		MessagesSent:      messages,
+		DailyMessageQuota: l.limits.DailyMessageQuota,
+		Limits:            limits,
+	}, nil
+}
+
+func (l *Limiter) allow(client string, method string) error {
+	limit := l.methodLimit(method)
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	l.prune(now)
+	key := bucketKey{client: client, method: strings.ToLower(method)}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), max(limit.Burst, 1))}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	reservation := b.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	return resourceExhausted(
+		fmt.Sprintf("rate limit of %s exceeded for %s", method, client),
+		delay,
+		&errdetails.QuotaFailure_Violation{
+			Subject:     client,
+			Description: fmt.Sprintf("%g requests per second to %s", limit.RequestsPerSecond, method),
+		},
+	)
+}
+
+// ReserveMessages takes messages from the daily quota of the caller before they are sent,
+// ResourceExhausted is returned if the quota can't hold them. Nothing is reserved if the quota isn't enforced.
+func ReserveMessages(ctx context.Context, messages int64) error {
+	r, ok := ctx.Value(reservationKey{}).(*reservation)
+	if !ok || messages <= 0 {
+		return nil
+	}
+	l := r.limiter
+
+	reserved, err := l.dao.NewClientUsageQuery().
+		ReserveMessages(ctx, r.client, r.day, messages, l.limits.DailyMessageQuota)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if !reserved {
+		return resourceExhausted(
+			fmt.Sprintf("daily message quota of %s can't hold %d messages", r.client, messages),
+			r.day.Add(24*time.Hour).Sub(time.Now()),
+			&errdetails.QuotaFailure_Violation{
+				Subject:     r.client,
+				Description: fmt.Sprintf("%d messages per day", l.limits.DailyMessageQuota),
+			},
+		)
+	}
+	r.mu.Lock()
+	r.reserved += messages
+	r.mu.Unlock()
+	return nil
+}
+
+// MessagesSent counts messages of the reservation of the call that are actually sent
+func MessagesSent(ctx context.Context, messages int64) {
+	r, ok := ctx.Value(reservationKey{}).(*reservation)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	r.sent += messages
+	r.mu.Unlock()
+}
+
+// release gives back reserved messages that weren't sent, the call may be canceled by then
+func (r *reservation) release(ctx context.Context) {
+	r.mu.Lock()
+	unsent := r.reserved - r.sent
+	r.mu.Unlock()
+	if unsent <= 0 {
+		return
+	}
+	// messages are already sent or failed, failing to release the rest must not fail the call
+	_ = r.limiter.dao.NewClientUsageQuery().AddMessages(context.WithoutCancel(ctx), r.client, r.day, -unsent)
+}
+
+// methodLimit returns the limit of the method, method names are matched in lower case as viper keys are
+func (l *Limiter) methodLimit(method string) config.RateLimit {
+	if limit, ok := l.limits.Methods[strings.ToLower(method)]; ok {
+		return limit
+	}
+	return l.limits.Default
+}
+
+// prune drops buckets of clients that have been idle for a while, l.mu must be held
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < idleBucketTTL {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleBucketTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func sendsMessages(req interface{}) bool {
+	type receiversRequest interface {
+		GetReceiverIds() []int64
+	}
+	_, ok := req.(receiversRequest)
+	return ok
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func resourceExhausted(message string, retryDelay time.Duration, violation *errdetails.QuotaFailure_Violation) error {
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{violation}},
+	)
+	if err != nil {
+		return errors.NewNetworkError(codes.ResourceExhausted, message).ToGRPCError()
+	}
+	return st.Err()
+}
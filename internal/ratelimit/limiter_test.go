@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+
+	desc "telegram-notification-api/api"
+)
+
+// usageDAO keeps message usage of clients in memory
+type usageDAO struct {
+	dao.DAO
+	messages map[string]int64
+}
+
+func (d *usageDAO) NewClientUsageQuery() dao.ClientUsageQuery {
+	return usageQuery{d: d}
+}
+
+type usageQuery struct {
+	dao.ClientUsageQuery
+	d *usageDAO
+}
+
+func (q usageQuery) AddMessages(_ context.Context, client string, _ time.Time, messages int64) error {
+	q.d.messages[client] += messages
+	return nil
+}
+
+func (q usageQuery) ReserveMessages(
+	_ context.Context,
+	client string,
+	_ time.Time,
+	messages int64,
+	quota int64,
+) (bool, error) {
+	if q.d.messages[client]+messages > quota {
+		return false, nil
+	}
+	q.d.messages[client] += messages
+	return true, nil
+}
+
+func (q usageQuery) GetMessages(_ context.Context, client string, _ time.Time) (int64, error) {
+	return q.d.messages[client], nil
+}
+
+func TestLimiterReservesMessages(t *testing.T) {
+	errSend := stdErrors.New("send failed")
+	tests := []struct {
+		name     string
+		used     int64
+		reserve  int64
+		sent     int64
+		sendErr  error
+		wantCode codes.Code
+		wantUsed int64
+	}{
+		{name: "all messages sent", reserve: 5, sent: 5, wantUsed: 5},
+		{name: "some messages sent", reserve: 5, sent: 3, wantUsed: 3},
+		{name: "quota filled up", used: 5, reserve: 5, sent: 5, wantUsed: 10},
+		{name: "failed call keeps sent messages", used: 1, reserve: 5, sent: 2, sendErr: errSend, wantUsed: 3},
+		{name: "receivers over the rest of quota", used: 8, reserve: 5, wantCode: codes.ResourceExhausted, wantUsed: 8},
+		{name: "receivers over quota", reserve: 11, wantCode: codes.ResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &usageDAO{messages: map[string]int64{"unknown": tt.used}}
+			l := NewLimiter(config.RateLimits{Enabled: true, DailyMessageQuota: 10}, d)
+
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				if err := ReserveMessages(ctx, tt.reserve); err != nil {
+					return nil, err
+				}
+				MessagesSent(ctx, tt.sent)
+				return &desc.SendNotificationResponse{}, tt.sendErr
+			}
+			_, err := l.UnaryServerInterceptor()(
+				context.Background(),
+				&desc.SendNotificationRequest{},
+				&grpc.UnaryServerInfo{FullMethod: "/notification.v1.telegram_notification_service/SendNotification"},
+				handler,
+			)
+
+			switch {
+			case tt.wantCode != codes.OK:
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("error = %v, want code %v", err, tt.wantCode)
+				}
+			case !stdErrors.Is(err, tt.sendErr):
+				t.Fatalf("error = %v, want %v", err, tt.sendErr)
+			}
+			if got := d.messages["unknown"]; got != tt.wantUsed {
+				t.Errorf("used messages = %d, want %d", got, tt.wantUsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) AddGroupModerator(
+	ctx context.Context,
+	req *desc.AddGroupModeratorRequest,
+) (*desc.AddGroupModeratorResponse, error) {
+	h, err := newAddGroupModeratorHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *addGroupModeratorHandler) response() *desc.AddGroupModeratorResponse {
+	return &desc.AddGroupModeratorResponse{}
+}
+
+func (h *addGroupModeratorHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		user, err := tx.NewUserQuery().GetUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if user.Role != desc.UserRole_GROUP_MODERATOR.String() {
+			return errors.NewNetworkError(
+				codes.FailedPrecondition,
+				fmt.Sprintf("user must have %s role", desc.UserRole_GROUP_MODERATOR),
+			).ToGRPCError()
+		}
+		groupQuery := tx.NewGroupQuery()
+		if _, err = groupQuery.GetGroup(h.ctx, h.groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		if err = groupQuery.AddModerator(h.ctx, h.userID, h.groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		moderator := map[string]interface{}{"moderator_id": h.userID}
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{h.groupID}, nil, moderator)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type addGroupModeratorHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID  int64
+	groupID int64
+}
+
+func newAddGroupModeratorHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.AddGroupModeratorRequest,
+) (*addGroupModeratorHandler, error) {
+	h := &addGroupModeratorHandler{
+		ctx:     ctx,
+		dao:     dao,
+		userID:  req.GetUserId(),
+		groupID: req.GetGroupId(),
+	}
+	return h, h.validate()
+}
+
+func (h *addGroupModeratorHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/dao"
+)
+
+func newDescApiClient(apiClient dao.ApiClientTable) *desc.ApiClient {
+	var userID *int64
+	if apiClient.UserID.Valid {
+		userID = &apiClient.UserID.Int64
+	}
+
+	return &desc.ApiClient{
+		ApiClientId: apiClient.ID,
+		Name:        apiClient.Name,
+		UserId:      userID,
+		CreatedAt:   timestamppb.New(apiClient.CreatedAt),
+	}
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) BatchCreateUsers(
+	ctx context.Context,
+	req *desc.BatchCreateUsersRequest,
+) (*desc.BatchCreateUsersResponse, error) {
+	h, err := newBatchCreateUsersHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *batchCreateUsersHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	if h.mode == desc.BatchMode_ATOMIC {
+		return h.createAtomically()
+	}
+
+	for idx, creator := range h.creators {
+		if h.items[idx].err != nil {
+			continue
+		}
+		if err := creator.handle(); err != nil {
+			h.items[idx].err = err
+			continue
+		}
+		h.items[idx].userID = creator.createdUser.Id
+		h.items[idx].user = &creator.createdUser
+	}
+	return nil
+}
+
+// createAtomically inserts all users with one statement and then adds them to their groups
+func (h *batchCreateUsersHandler) createAtomically() error {
+	for idx := range h.items {
+		if h.items[idx].err != nil {
+			return batchItemError(idx, h.items[idx].err)
+		}
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+		groupQuery := tx.NewGroupQuery()
+
+		users := make([]dao.UserTable, 0, len(h.creators))
+		for _, creator := range h.creators {
+			users = append(users, dao.UserTable{
+				TelegramId:         creator.TelegramId,
+				Role:               creator.UserRole,
+				NotificationStatus: creator.UserNotificationStatus,
+				Firstname:          creator.Fio.Firstname,
+				Surname:            creator.Fio.Surname,
+				Patronymic:         creator.Fio.Patronymic,
+				MobilePhone:        creator.MobilePhone,
+				Status:             creator.UserStatus,
+			})
+		}
+		created, err := userQuery.CreateUsers(h.ctx, users)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		// telegram ids are unique within the batch, they match created rows to items
+		userIDs := make(map[int64]int64, len(created))
+		for _, user := range created {
+			userIDs[user.TelegramId] = user.Id
+		}
+
+		ids := make([]int64, 0, len(h.creators))
+		for idx, creator := range h.creators {
+			userID := userIDs[creator.TelegramId]
+			if err = addUserToGroups(h.ctx, groupQuery, userID, creator.Groups); err != nil {
+				return batchItemError(idx, err)
+			}
+			ids = append(ids, userID)
+		}
+
+		after, err := getBatchUsers(h.ctx, tx, ids, false)
+		if err != nil {
+			return err
+		}
+		for idx := range after {
+			err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{after[idx].Id}, nil, after[idx])
+			if err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			if err = enqueueUserCreated(h.ctx, tx, after[idx]); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			h.items[idx].userID = after[idx].Id
+			h.items[idx].user = &after[idx]
+		}
+		return nil
+	})
+}
+
+func (h *batchCreateUsersHandler) response() *desc.BatchCreateUsersResponse {
+	results, succeeded, failed := newBatchUserResults(h.items)
+	return &desc.BatchCreateUsersResponse{
+		Results:   results,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+type batchCreateUsersHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	mode     desc.BatchMode
+	creators []*createUserHandler
+
+	items []batchItem
+}
+
+func newBatchCreateUsersHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.BatchCreateUsersRequest,
+) (*batchCreateUsersHandler, error) {
+	h := &batchCreateUsersHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *batchCreateUsersHandler) adapt(req *desc.BatchCreateUsersRequest) *batchCreateUsersHandler {
+	h.mode = req.GetMode()
+	h.creators = make([]*createUserHandler, 0, len(req.GetUsers()))
+	h.items = make([]batchItem, len(req.GetUsers()))
+
+	seen := make(map[int64]int)
+	for idx, userReq := range req.GetUsers() {
+		creator, err := newCreateUserHandler(h.ctx, h.dao, userReq)
+		h.creators = append(h.creators, creator)
+		if err != nil {
+			h.items[idx].err = err
+			continue
+		}
+		if first, ok := seen[creator.TelegramId]; ok {
+			h.items[idx].err = errors.
+				NewNetworkError(codes.InvalidArgument, fmt.Sprintf("telegram_id is duplicated in item %d", first)).
+				ToGRPCError()
+			continue
+		}
+		seen[creator.TelegramId] = idx
+	}
+	return h
+}
+
+func (h *batchCreateUsersHandler) validate() error {
+	if len(h.creators) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "users must be specified").
+			ToGRPCError()
+	}
+	if len(h.creators) > maxBatchSize {
+		return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("at most %d users are allowed", maxBatchSize)).
+			ToGRPCError()
+	}
+	return validateBatchMode(h.mode)
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) BatchDeleteUsers(
+	ctx context.Context,
+	req *desc.BatchDeleteUsersRequest,
+) (*desc.BatchDeleteUsersResponse, error) {
+	h, err := newBatchDeleteUsersHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *batchDeleteUsersHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	if h.filter != nil {
+		var err error
+		if h.userIDs, err = findBatchUserIDs(h.ctx, h.dao, *h.filter); err != nil {
+			return err
+		}
+	}
+	h.items = make([]batchItem, len(h.userIDs))
+	for idx, userID := range h.userIDs {
+		h.items[idx].userID = userID
+	}
+
+	if h.mode == desc.BatchMode_ATOMIC {
+		return h.deleteAtomically()
+	}
+
+	for idx, userID := range h.userIDs {
+		deleter, err := newDeleteUserHandler(h.ctx, h.dao, &desc.DeleteUserRequest{UserId: userID})
+		if err == nil {
+			err = deleter.handle()
+		}
+		h.items[idx].err = err
+	}
+	return nil
+}
+
+func (h *batchDeleteUsersHandler) deleteAtomically() error {
+	if len(h.userIDs) == 0 {
+		return nil
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		before, err := getBatchUsers(h.ctx, tx, h.userIDs, false)
+		if err != nil {
+			return err
+		}
+		var deletedBy sql.NullInt64
+		if actor, ok := authz.ActorFromContext(h.ctx); ok {
+			deletedBy = sql.NullInt64{Int64: actor.Id, Valid: true}
+		}
+		if _, err = tx.NewUserQuery().DeleteUsers(h.ctx, h.userIDs, deletedBy); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		after, err := getBatchUsers(h.ctx, tx, h.userIDs, true)
+		if err != nil {
+			return err
+		}
+		for idx := range after {
+			err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{after[idx].Id}, before[idx], after[idx])
+			if err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			h.items[idx].user = &after[idx]
+		}
+		return nil
+	})
+}
+
+func (h *batchDeleteUsersHandler) response() *desc.BatchDeleteUsersResponse {
+	results, succeeded, failed := newBatchUserResults(h.items)
+	return &desc.BatchDeleteUsersResponse{
+		Results:   results,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+type batchDeleteUsersHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	mode    desc.BatchMode
+	userIDs []int64
+	filter  *dao.UserFilter
+
+	items []batchItem
+}
+
+func newBatchDeleteUsersHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.BatchDeleteUsersRequest,
+) (*batchDeleteUsersHandler, error) {
+	h := &batchDeleteUsersHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *batchDeleteUsersHandler) adapt(req *desc.BatchDeleteUsersRequest) *batchDeleteUsersHandler {
+	h.mode = req.GetMode()
+	h.userIDs = req.GetUserIds()
+	if req.Filter != nil {
+		filter := newUserFilter(req.GetFilter())
+		h.filter = &filter
+	}
+	return h
+}
+
+func (h *batchDeleteUsersHandler) validate() error {
+	if (len(h.userIDs) > 0) == (h.filter != nil) {
+		return errors.NewNetworkError(codes.InvalidArgument, "exactly one of user_ids and filter must be specified").
+			ToGRPCError()
+	}
+	if err := validateBatchUserIDs(h.userIDs); err != nil {
+		return err
+	}
+	if h.filter != nil {
+		if err := validateUserFilter(*h.filter); err != nil {
+			return err
+		}
+	}
+	return validateBatchMode(h.mode)
+}
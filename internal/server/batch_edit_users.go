@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) BatchEditUsers(
+	ctx context.Context,
+	req *desc.BatchEditUsersRequest,
+) (*desc.BatchEditUsersResponse, error) {
+	h, err := newBatchEditUsersHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *batchEditUsersHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	if err := h.prepareEditors(); err != nil {
+		return err
+	}
+
+	if h.mode == desc.BatchMode_ATOMIC {
+		return h.editAtomically()
+	}
+
+	for idx, editor := range h.editors {
+		if h.items[idx].err != nil {
+			continue
+		}
+		if err := editor.handle(); err != nil {
+			h.items[idx].err = err
+			continue
+		}
+		h.items[idx].user = &editor.editedUser
+	}
+	return nil
+}
+
+// prepareEditors makes an EditUser handler for every target user
+func (h *batchEditUsersHandler) prepareEditors() error {
+	edits := h.edits
+	if h.changes != nil {
+		userIDs := h.userIDs
+		if h.filter != nil {
+			var err error
+			if userIDs, err = findBatchUserIDs(h.ctx, h.dao, *h.filter); err != nil {
+				return err
+			}
+		}
+
+		edits = make([]*desc.EditUserRequest, 0, len(userIDs))
+		for _, userID := range userIDs {
+			edit := proto.Clone(h.changes).(*desc.EditUserRequest)
+			edit.UserId = userID
+			edits = append(edits, edit)
+		}
+	}
+
+	h.editors = make([]*editUserHandler, 0, len(edits))
+	h.items = make([]batchItem, len(edits))
+	seen := make(map[int64]int)
+	for idx, edit := range edits {
+		editor, err := newEditUserHandler(h.ctx, h.dao, edit)
+		h.editors = append(h.editors, editor)
+		h.items[idx].userID = edit.GetUserId()
+		if err != nil {
+			// changes are the same for every user, so they are invalid for the whole request
+			if h.changes != nil {
+				return err
+			}
+			h.items[idx].err = err
+			continue
+		}
+		if first, ok := seen[edit.GetUserId()]; ok {
+			h.items[idx].err = errors.
+				NewNetworkError(codes.InvalidArgument, fmt.Sprintf("user_id is duplicated in item %d", first)).
+				ToGRPCError()
+			continue
+		}
+		seen[edit.GetUserId()] = idx
+	}
+	return nil
+}
+
+// editAtomically changes users with a statement per set of edited fields and then replaces their groups
+func (h *batchEditUsersHandler) editAtomically() error {
+	for idx := range h.items {
+		if h.items[idx].err != nil {
+			return batchItemError(idx, h.items[idx].err)
+		}
+	}
+	if len(h.editors) == 0 {
+		return nil
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+		groupQuery := tx.NewGroupQuery()
+
+		userIDs := make([]int64, 0, len(h.editors))
+		for _, editor := range h.editors {
+			userIDs = append(userIDs, editor.userToEdit.Id)
+		}
+		before, err := getBatchUsers(h.ctx, tx, userIDs, false)
+		if err != nil {
+			return err
+		}
+
+		if h.changes != nil {
+			editor := h.editors[0]
+			if len(editor.fields) > 0 {
+				if _, err = userQuery.ChangeUsers(h.ctx, editor.userToEdit, userIDs, editor.fields...); err != nil {
+					return errors.WrapToNetwork(err).ToGRPCError()
+				}
+			}
+		} else {
+			if err = h.changeEachUser(userQuery); err != nil {
+				return err
+			}
+		}
+
+		for idx, editor := range h.editors {
+			if editor.group == nil {
+				continue
+			}
+			if err = groupQuery.RemoveAllMemberships(h.ctx, editor.userToEdit.Id); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			err = addUserToGroups(h.ctx, groupQuery, editor.userToEdit.Id, []string{*editor.group})
+			if err != nil {
+				return batchItemError(idx, err)
+			}
+		}
+
+		after, err := getBatchUsers(h.ctx, tx, userIDs, false)
+		if err != nil {
+			return err
+		}
+		for idx := range after {
+			err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{after[idx].Id}, before[idx], after[idx])
+			if err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			h.items[idx].user = &after[idx]
+		}
+		return nil
+	})
+}
+
+// changeEachUser groups per-user edits by edited fields, every group is changed with one statement
+func (h *batchEditUsersHandler) changeEachUser(userQuery dao.UserQuery) error {
+	var keys []string
+	fields := make(map[string][]string)
+	users := make(map[string][]dao.UserTable)
+	for _, editor := range h.editors {
+		if len(editor.fields) == 0 {
+			continue
+		}
+		key := strings.Join(editor.fields, ",")
+		if _, ok := users[key]; !ok {
+			keys = append(keys, key)
+			fields[key] = editor.fields
+		}
+		users[key] = append(users[key], editor.userToEdit)
+	}
+
+	for _, key := range keys {
+		if _, err := userQuery.ChangeEachUser(h.ctx, users[key], fields[key]...); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+	return nil
+}
+
+func (h *batchEditUsersHandler) response() *desc.BatchEditUsersResponse {
+	results, succeeded, failed := newBatchUserResults(h.items)
+	return &desc.BatchEditUsersResponse{
+		Results:   results,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+type batchEditUsersHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	mode    desc.BatchMode
+	userIDs []int64
+	filter  *dao.UserFilter
+	changes *desc.EditUserRequest
+	edits   []*desc.EditUserRequest
+
+	editors []*editUserHandler
+	items   []batchItem
+}
+
+func newBatchEditUsersHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.BatchEditUsersRequest,
+) (*batchEditUsersHandler, error) {
+	h := &batchEditUsersHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *batchEditUsersHandler) adapt(req *desc.BatchEditUsersRequest) *batchEditUsersHandler {
+	h.mode = req.GetMode()
+	h.userIDs = req.GetUserIds()
+	if req.Filter != nil {
+		filter := newUserFilter(req.GetFilter())
+		h.filter = &filter
+	}
+	h.changes = req.GetChanges()
+	h.edits = req.GetEdits()
+	return h
+}
+
+func (h *batchEditUsersHandler) validate() error {
+	targets := 0
+	if len(h.userIDs) > 0 {
+		targets++
+	}
+	if h.filter != nil {
+		targets++
+	}
+	if len(h.edits) > 0 {
+		targets++
+	}
+	if targets != 1 {
+		return errors.
+			NewNetworkError(codes.InvalidArgument, "exactly one of user_ids, filter and edits must be specified").
+			ToGRPCError()
+	}
+	if len(h.edits) > 0 && h.changes != nil {
+		return errors.NewNetworkError(codes.InvalidArgument, "changes can't be used with edits").
+			ToGRPCError()
+	}
+	if len(h.edits) == 0 && h.changes == nil {
+		return errors.NewNetworkError(codes.InvalidArgument, "changes must be specified").
+			ToGRPCError()
+	}
+	if len(h.edits) > maxBatchSize {
+		return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("at most %d edits are allowed", maxBatchSize)).
+			ToGRPCError()
+	}
+	if err := validateBatchUserIDs(h.userIDs); err != nil {
+		return err
+	}
+	if h.filter != nil {
+		if err := validateUserFilter(*h.filter); err != nil {
+			return err
+		}
+	}
+	return validateBatchMode(h.mode)
+}
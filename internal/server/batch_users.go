@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+// maxBatchSize limits the number of items of a batch, including users matched by a filter
+const maxBatchSize = 1000
+
+// batchItem is a result of an item of a batch
+type batchItem struct {
+	userID int64
+	user   *dao.UserTable
+	err    error
+}
+
+func newBatchUserResults(items []batchItem) (results []*desc.BatchUserResult, succeeded int64, failed int64) {
+	results = make([]*desc.BatchUserResult, 0, len(items))
+	for idx, item := range items {
+		result := &desc.BatchUserResult{
+			Index:  int64(idx),
+			UserId: item.userID,
+		}
+		if item.user != nil {
+			result.User = newDescUser(*item.user)
+		}
+		if item.err != nil {
+			s := status.Convert(item.err)
+			result.ErrorCode = int32(s.Code())
+			result.Error = s.Message()
+			failed++
+		} else {
+			succeeded++
+		}
+		results = append(results, result)
+	}
+	return results, succeeded, failed
+}
+
+// batchItemError adds the index of the failed item to err keeping its code,
+// it's returned when an item fails an atomic batch
+func batchItemError(idx int, err error) error {
+	s := status.Convert(err)
+	return errors.NewNetworkError(s.Code(), fmt.Sprintf("item %d: %s", idx, s.Message())).
+		ToGRPCError()
+}
+
+func validateBatchMode(mode desc.BatchMode) error {
+	if _, ok := desc.BatchMode_name[int32(mode)]; !ok {
+		return errors.NewNetworkError(codes.InvalidArgument, "unknown mode").
+			ToGRPCError()
+	}
+	return nil
+}
+
+// validateBatchUserIDs checks ids of target users given in a request
+func validateBatchUserIDs(userIDs []int64) error {
+	if len(userIDs) > maxBatchSize {
+		return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("at most %d user_ids are allowed", maxBatchSize)).
+			ToGRPCError()
+	}
+	seen := make(map[int64]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		if userID <= 0 {
+			return errors.NewNetworkError(codes.InvalidArgument, "user_ids must be positive").
+				ToGRPCError()
+		}
+		if _, ok := seen[userID]; ok {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("user_id %d is duplicated", userID)).
+				ToGRPCError()
+		}
+		seen[userID] = struct{}{}
+	}
+	return nil
+}
+
+// findBatchUserIDs returns ids of users matching the filter ordered by id
+func findBatchUserIDs(ctx context.Context, d dao.DAO, filter dao.UserFilter) ([]int64, error) {
+	users, err := d.NewUserQuery().GetUsersByFilterAfter(ctx, filter, dao.Keyset{}, maxBatchSize+1)
+	if err != nil {
+		return nil, errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if len(users) > maxBatchSize {
+		return nil, errors.
+			NewNetworkError(codes.InvalidArgument, fmt.Sprintf("filter matches more than %d users", maxBatchSize)).
+			ToGRPCError()
+	}
+
+	userIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+	return userIDs, nil
+}
+
+// getBatchUsers returns users with the ids in the order of ids, a missing user fails the item
+func getBatchUsers(ctx context.Context, d dao.DAO, userIDs []int64, includeDeleted bool) ([]dao.UserTable, error) {
+	users, err := d.NewUserQuery().GetUsersByIds(ctx, userIDs, includeDeleted, nil, uint64(len(userIDs)), 0)
+	if err != nil {
+		return nil, errors.WrapToNetwork(err).ToGRPCError()
+	}
+	byID := make(map[int64]dao.UserTable, len(users))
+	for _, user := range users {
+		byID[user.Id] = user
+	}
+
+	ordered := make([]dao.UserTable, 0, len(userIDs))
+	for idx, userID := range userIDs {
+		user, ok := byID[userID]
+		if !ok {
+			return nil, batchItemError(
+				idx,
+				errors.NewNetworkError(codes.NotFound, fmt.Sprintf("user %d not found", userID)).ToGRPCError(),
+			)
+		}
+		ordered = append(ordered, user)
+	}
+	return ordered, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) CreateApiClient(
+	ctx context.Context,
+	req *desc.CreateApiClientRequest,
+) (*desc.CreateApiClientResponse, error) {
+	h, err := newCreateApiClientHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *createApiClientHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if h.userID.Valid {
+		if _, err := h.dao.NewUserQuery().GetUser(h.ctx, h.userID.Int64); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+
+	key, hash, err := auth.GenerateApiKey()
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.apiKey = key
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		apiClient, err := tx.NewApiClientQuery().CreateApiClient(h.ctx, h.name, hash, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.createdApiClient = apiClient
+
+		err = audit.Record(h.ctx, tx, audit.TargetApiClient, []int64{apiClient.ID}, nil, apiClient)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *createApiClientHandler) response() *desc.CreateApiClientResponse {
+	return &desc.CreateApiClientResponse{
+		ApiClient: newDescApiClient(h.createdApiClient),
+		ApiKey:    h.apiKey,
+	}
+}
+
+type createApiClientHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	name   string
+	userID sql.NullInt64
+
+	createdApiClient dao.ApiClientTable
+	apiKey           string
+}
+
+func newCreateApiClientHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.CreateApiClientRequest,
+) (*createApiClientHandler, error) {
+	h := &createApiClientHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *createApiClientHandler) adapt(req *desc.CreateApiClientRequest) *createApiClientHandler {
+	h.name = req.GetName()
+	h.userID = nulltypes.NewNullInt64(req.UserId)
+	return h
+}
+
+func (h *createApiClientHandler) validate() error {
+	if h.name == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "name must be specified").
+			ToGRPCError()
+	}
+	if h.userID.Valid && h.userID.Int64 <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be positive").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) CreateGroup(
+	ctx context.Context,
+	req *desc.CreateGroupRequest,
+) (*desc.CreateGroupResponse, error) {
+	h, err := newCreateGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *createGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+
+		if h.parentID.Valid {
+			if _, err := groupQuery.GetGroup(h.ctx, h.parentID.Int64); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+		}
+
+		group, err := groupQuery.CreateGroup(h.ctx, h.name, h.description, h.parentID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.createdGroup = dao.GroupMembersTable{GroupTable: group}
+
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{group.ID}, nil, group)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *createGroupHandler) response() *desc.CreateGroupResponse {
+	return &desc.CreateGroupResponse{
+		Group: newDescGroup(h.createdGroup),
+	}
+}
+
+type createGroupHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	name        string
+	description sql.NullString
+	parentID    sql.NullInt64
+
+	createdGroup dao.GroupMembersTable
+}
+
+func newCreateGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.CreateGroupRequest,
+) (*createGroupHandler, error) {
+	h := &createGroupHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *createGroupHandler) adapt(req *desc.CreateGroupRequest) *createGroupHandler {
+	h.name = req.GetName()
+	h.description = nulltypes.NewNullString(req.Description)
+	h.parentID = nulltypes.NewNullInt64(req.ParentGroupId)
+	return h
+}
+
+func (h *createGroupHandler) validate() error {
+	if h.name == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "name must be specified").
+			ToGRPCError()
+	}
+	if h.parentID.Valid && h.parentID.Int64 <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "parent_group_id must be positive").
+			ToGRPCError()
+	}
+	return nil
+}
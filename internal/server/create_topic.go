@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) CreateTopic(
+	ctx context.Context,
+	req *desc.CreateTopicRequest,
+) (*desc.CreateTopicResponse, error) {
+	h, err := newCreateTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *createTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		topic, err := tx.NewTopicQuery().CreateTopic(h.ctx, h.name, h.description)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.createdTopic = topic
+
+		err = audit.Record(h.ctx, tx, audit.TargetTopic, []int64{topic.ID}, nil, topic)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *createTopicHandler) response() *desc.CreateTopicResponse {
+	var description *string
+	if h.createdTopic.Description.Valid {
+		description = &h.createdTopic.Description.String
+	}
+	return &desc.CreateTopicResponse{
+		Topic: &desc.Topic{
+			TopicId:     h.createdTopic.ID,
+			Name:        h.createdTopic.Name,
+			Description: description,
+		},
+	}
+}
+
+type createTopicHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	name        string
+	description sql.NullString
+
+	createdTopic dao.TopicTable
+}
+
+func newCreateTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.CreateTopicRequest,
+) (*createTopicHandler, error) {
+	h := &createTopicHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *createTopicHandler) adapt(req *desc.CreateTopicRequest) *createTopicHandler {
+	h.name = req.GetName()
+	h.description = nulltypes.NewNullString(req.Description)
+	return h
+}
+
+func (h *createTopicHandler) validate() error {
+	if h.name == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "name must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"telegram-notification-api/internal/audit"
 	"telegram-notification-api/internal/types/nulltypes"
 
 	"google.golang.org/grpc/codes"
@@ -28,48 +30,43 @@ func (h *createUserHandler) handle() error {
 	if h == nil {
 		return fmt.Errorf("got nil receiver")
 	}
-	u, err := h.dao.
-		NewUserQuery().
-		CreateUser(
-			h.ctx,
-			h.TelegramId,
-			h.UserRole,
-			h.UserNotificationStatus,
-			h.Group,
-			h.Fio.Firstname,
-			h.Fio.Surname,
-			h.Fio.Patronymic,
-			h.MobilePhone,
-			h.UserStatus,
-		)
-	if err != nil {
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+		u, err := userQuery.
+			CreateUser(
+				h.ctx,
+				h.TelegramId,
+				h.UserRole,
+				h.UserNotificationStatus,
+				h.Fio.Firstname,
+				h.Fio.Surname,
+				h.Fio.Patronymic,
+				h.MobilePhone,
+				h.UserStatus,
+			)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = addUserToGroups(h.ctx, tx.NewGroupQuery(), u.Id, h.Groups); err != nil {
+			return err
+		}
+
+		h.createdUser, err = userQuery.GetUser(h.ctx, u.Id)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = enqueueUserCreated(h.ctx, tx, h.createdUser); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{u.Id}, nil, h.createdUser)
 		return errors.WrapToNetwork(err).ToGRPCError()
-	}
-	h.createdUser = u
-	return nil
+	})
 }
 
 func (h *createUserHandler) response() *desc.CreateUserResponse {
-	var patronymic *string
-	if h.createdUser.Patronymic.Valid {
-		patronymic = &h.createdUser.Patronymic.String
-	}
-
 	return &desc.CreateUserResponse{
-		User: &desc.User{
-			UserId:                 h.createdUser.Id,
-			TelegramId:             h.createdUser.TelegramId,
-			UserRole:               desc.UserRole(desc.UserRole_value[h.createdUser.Role]),
-			UserNotificationStatus: desc.UserNotificationStatus(desc.NotificationStatus_value[h.createdUser.NotificationStatus]),
-			Group:                  h.createdUser.Group,
-			Fio: &desc.FIO{
-				Firstname:  h.createdUser.Firstname,
-				Surname:    h.createdUser.Surname,
-				Patronymic: patronymic,
-			},
-			MobilePhone: h.createdUser.MobilePhone,
-			UserStatus:  desc.UserStatus(desc.UserRole_value[h.createdUser.Status]),
-		},
+		User: newDescUser(h.createdUser),
 	}
 }
 
@@ -80,7 +77,7 @@ type createUserHandler struct {
 	TelegramId             int64
 	UserRole               string
 	UserNotificationStatus string
-	Group                  string
+	Groups                 []string
 	Fio                    createUserHandlerFIO
 	MobilePhone            string
 	UserStatus             string
@@ -109,7 +106,11 @@ func (h *createUserHandler) adapt(req *desc.CreateUserRequest) *createUserHandle
 	h.TelegramId = req.GetTelegramId()
 	h.UserRole = req.GetUserRole().String()
 	h.UserNotificationStatus = req.GetUserNotificationStatus().String()
-	h.Group = req.GetGroup()
+	h.Groups = req.GetGroups()
+	// group is deprecated but still accepted from old clients
+	if group := req.GetGroup(); group != "" && !slices.Contains(h.Groups, group) {
+		h.Groups = append([]string{group}, h.Groups...)
+	}
 	h.MobilePhone = req.GetMobilePhone()
 	h.UserStatus = desc.UserStatus_ACTIVE.String()
 	h.Fio = createUserHandlerFIO{
@@ -125,8 +126,12 @@ func (h *createUserHandler) validate() error {
 		return errors.NewNetworkError(codes.InvalidArgument, "telegram_id must be specified").
 			ToGRPCError()
 	}
-	if h.Group == "" {
-		return errors.NewNetworkError(codes.InvalidArgument, "group must be specified").
+	if len(h.Groups) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "groups must be specified").
+			ToGRPCError()
+	}
+	if slices.Contains(h.Groups, "") {
+		return errors.NewNetworkError(codes.InvalidArgument, "groups must not contain empty names").
 			ToGRPCError()
 	}
 	if h.MobilePhone == "" {
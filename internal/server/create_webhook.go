@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) CreateWebhook(
+	ctx context.Context,
+	req *desc.CreateWebhookRequest,
+) (*desc.CreateWebhookResponse, error) {
+	h, err := newCreateWebhookHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *createWebhookHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		webhook, err := tx.NewWebhookQuery().CreateWebhook(h.ctx, h.url, h.eventTypes, h.secret, time.Now())
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.createdWebhook = webhook
+
+		err = audit.Record(h.ctx, tx, audit.TargetWebhook, []int64{webhook.ID}, nil, webhook)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *createWebhookHandler) response() *desc.CreateWebhookResponse {
+	return &desc.CreateWebhookResponse{
+		Webhook: newDescWebhook(h.createdWebhook),
+	}
+}
+
+type createWebhookHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	url        string
+	eventTypes []string
+	secret     string
+
+	createdWebhook dao.WebhookTable
+}
+
+func newCreateWebhookHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.CreateWebhookRequest,
+) (*createWebhookHandler, error) {
+	h := &createWebhookHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *createWebhookHandler) adapt(req *desc.CreateWebhookRequest) *createWebhookHandler {
+	h.url = req.GetUrl()
+	h.eventTypes = distinctEventTypes(req.GetEventTypes())
+	h.secret = req.GetSecret()
+	return h
+}
+
+func (h *createWebhookHandler) validate() error {
+	if err := validateWebhookURL(h.url); err != nil {
+		return err
+	}
+	if err := validateWebhookEventTypes(h.eventTypes); err != nil {
+		return err
+	}
+	if h.secret == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "secret must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) DeleteApiClient(
+	ctx context.Context,
+	req *desc.DeleteApiClientRequest,
+) (*desc.DeleteApiClientResponse, error) {
+	h, err := newDeleteApiClientHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *deleteApiClientHandler) response() *desc.DeleteApiClientResponse {
+	return &desc.DeleteApiClientResponse{}
+}
+
+func (h *deleteApiClientHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		apiClientQuery := tx.NewApiClientQuery()
+
+		before, err := apiClientQuery.GetApiClient(h.ctx, h.apiClientID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = apiClientQuery.DeleteApiClient(h.ctx, h.apiClientID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetApiClient, []int64{h.apiClientID}, before, nil)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type deleteApiClientHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	apiClientID int64
+}
+
+func newDeleteApiClientHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.DeleteApiClientRequest,
+) (*deleteApiClientHandler, error) {
+	h := &deleteApiClientHandler{
+		ctx:         ctx,
+		dao:         dao,
+		apiClientID: req.GetApiClientId(),
+	}
+	return h, h.validate()
+}
+
+func (h *deleteApiClientHandler) validate() error {
+	if h.apiClientID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "api_client_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) DeleteGroup(
+	ctx context.Context,
+	req *desc.DeleteGroupRequest,
+) (*desc.DeleteGroupResponse, error) {
+	h, err := newDeleteGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *deleteGroupHandler) response() *desc.DeleteGroupResponse {
+	return &desc.DeleteGroupResponse{}
+}
+
+func (h *deleteGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+
+		group, err := groupQuery.GetGroup(h.ctx, h.groupID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if group.MemberCount > 0 {
+			return errors.NewNetworkError(codes.FailedPrecondition, "group has members, move them first").
+				ToGRPCError()
+		}
+		hasSubgroups, err := groupQuery.HasSubgroups(h.ctx, h.groupID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if hasSubgroups {
+			return errors.NewNetworkError(codes.FailedPrecondition, "group has subgroups, move them first").
+				ToGRPCError()
+		}
+
+		if err = groupQuery.DeleteGroup(h.ctx, h.groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{h.groupID}, group.GroupTable, nil)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type deleteGroupHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	groupID int64
+}
+
+func newDeleteGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.DeleteGroupRequest,
+) (*deleteGroupHandler, error) {
+	h := &deleteGroupHandler{
+		ctx:     ctx,
+		dao:     dao,
+		groupID: req.GetGroupId(),
+	}
+	return h, h.validate()
+}
+
+func (h *deleteGroupHandler) validate() error {
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
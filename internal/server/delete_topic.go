@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) DeleteTopic(
+	ctx context.Context,
+	req *desc.DeleteTopicRequest,
+) (*desc.DeleteTopicResponse, error) {
+	h, err := newDeleteTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *deleteTopicHandler) response() *desc.DeleteTopicResponse {
+	return &desc.DeleteTopicResponse{}
+}
+
+func (h *deleteTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		topicQuery := tx.NewTopicQuery()
+
+		before, err := topicQuery.GetTopic(h.ctx, h.topicID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = topicQuery.DeleteTopic(h.ctx, h.topicID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetTopic, []int64{h.topicID}, before, nil)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type deleteTopicHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	topicID int64
+}
+
+func newDeleteTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.DeleteTopicRequest,
+) (*deleteTopicHandler, error) {
+	h := &deleteTopicHandler{
+		ctx:     ctx,
+		dao:     dao,
+		topicID: req.GetTopicId(),
+	}
+	return h, h.validate()
+}
+
+func (h *deleteTopicHandler) validate() error {
+	if h.topicID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
 
@@ -33,8 +36,29 @@ func (h *deleteUserHandler) handle() error {
 	if h == nil {
 		return fmt.Errorf("got nil receiver")
 	}
-	err := h.dao.NewUserQuery().DeleteUser(h.ctx, h.userID)
-	return errors.WrapToNetwork(err).ToGRPCError()
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+
+		before, err := userQuery.GetUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		var deletedBy sql.NullInt64
+		if actor, ok := authz.ActorFromContext(h.ctx); ok {
+			deletedBy = sql.NullInt64{Int64: actor.Id, Valid: true}
+		}
+		if err = userQuery.DeleteUser(h.ctx, h.userID, deletedBy); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		after, err := userQuery.GetUserIncludingDeleted(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{h.userID}, before, after)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
 }
 
 type deleteUserHandler struct {
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) DeleteWebhook(
+	ctx context.Context,
+	req *desc.DeleteWebhookRequest,
+) (*desc.DeleteWebhookResponse, error) {
+	h, err := newDeleteWebhookHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *deleteWebhookHandler) response() *desc.DeleteWebhookResponse {
+	return &desc.DeleteWebhookResponse{}
+}
+
+// handle deletes the webhook together with its deliveries
+func (h *deleteWebhookHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		webhookQuery := tx.NewWebhookQuery()
+
+		before, err := webhookQuery.GetWebhook(h.ctx, h.webhookID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = webhookQuery.DeleteWebhook(h.ctx, h.webhookID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetWebhook, []int64{h.webhookID}, before, nil)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type deleteWebhookHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	webhookID int64
+}
+
+func newDeleteWebhookHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.DeleteWebhookRequest,
+) (*deleteWebhookHandler, error) {
+	h := &deleteWebhookHandler{
+		ctx:       ctx,
+		dao:       dao,
+		webhookID: req.GetWebhookId(),
+	}
+	return h, h.validate()
+}
+
+func (h *deleteWebhookHandler) validate() error {
+	if h.webhookID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "webhook_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
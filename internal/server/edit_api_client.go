@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/auth"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) EditApiClient(
+	ctx context.Context,
+	req *desc.EditApiClientRequest,
+) (*desc.EditApiClientResponse, error) {
+	h, err := newEditApiClientHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *editApiClientHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if h.apiClientToEdit.UserID.Valid {
+		if _, err := h.dao.NewUserQuery().GetUser(h.ctx, h.apiClientToEdit.UserID.Int64); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+	if h.rotateKey {
+		key, hash, err := auth.GenerateApiKey()
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.apiKey = &key
+		h.apiClientToEdit.KeyHash = hash
+		h.fields = append(h.fields, "key_hash")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		apiClientQuery := tx.NewApiClientQuery()
+
+		before, err := apiClientQuery.GetApiClient(h.ctx, h.apiClientToEdit.ID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		apiClient, err := apiClientQuery.ChangeApiClient(h.ctx, h.apiClientToEdit, h.fields...)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.editedApiClient = apiClient
+
+		err = audit.Record(h.ctx, tx, audit.TargetApiClient, []int64{apiClient.ID}, before, apiClient)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *editApiClientHandler) response() *desc.EditApiClientResponse {
+	return &desc.EditApiClientResponse{
+		ApiClient: newDescApiClient(h.editedApiClient),
+		ApiKey:    h.apiKey,
+	}
+}
+
+type editApiClientHandler struct {
+	ctx             context.Context
+	dao             dao.DAO
+	fields          []string
+	apiClientToEdit dao.ApiClientTable
+	rotateKey       bool
+
+	editedApiClient dao.ApiClientTable
+	apiKey          *string
+}
+
+func newEditApiClientHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.EditApiClientRequest,
+) (*editApiClientHandler, error) {
+	h := &editApiClientHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *editApiClientHandler) adapt(req *desc.EditApiClientRequest) *editApiClientHandler {
+	h.apiClientToEdit.ID = req.GetApiClientId()
+	h.rotateKey = req.GetRotateKey()
+
+	if req.Name != nil {
+		h.apiClientToEdit.Name = req.GetName()
+		h.fields = append(h.fields, "name")
+	}
+	if req.UserId != nil {
+		h.apiClientToEdit.UserID = nulltypes.NewNullInt64(req.UserId)
+		h.fields = append(h.fields, "user_id")
+	}
+	return h
+}
+
+func (h *editApiClientHandler) validate() error {
+	if h.apiClientToEdit.ID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "api_client_id must be specified").
+			ToGRPCError()
+	}
+	if len(h.fields) == 0 && !h.rotateKey {
+		return errors.NewNetworkError(codes.InvalidArgument, "nothing to edit").
+			ToGRPCError()
+	}
+	for _, field := range h.fields {
+		if field == "name" && h.apiClientToEdit.Name == "" {
+			return errors.NewNetworkError(codes.InvalidArgument, "name must not be empty").
+				ToGRPCError()
+		}
+		if field == "user_id" && h.apiClientToEdit.UserID.Int64 <= 0 {
+			return errors.NewNetworkError(codes.InvalidArgument, "user_id must be positive").
+				ToGRPCError()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"slices"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) EditGroup(
+	ctx context.Context,
+	req *desc.EditGroupRequest,
+) (*desc.EditGroupResponse, error) {
+	h, err := newEditGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *editGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+
+		before, err := groupQuery.GetGroup(h.ctx, h.groupToEdit.ID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		if h.groupToEdit.ParentID.Valid {
+			if err := h.checkParent(groupQuery); err != nil {
+				return err
+			}
+		}
+
+		if _, err := groupQuery.ChangeGroup(h.ctx, h.groupToEdit, h.fields...); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		group, err := groupQuery.GetGroup(h.ctx, h.groupToEdit.ID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.editedGroup = group
+
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{group.ID}, before.GroupTable, group.GroupTable)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+// checkParent makes sure the new parent exists and is not the group itself or one of its descendants
+func (h *editGroupHandler) checkParent(groupQuery dao.GroupQuery) error {
+	parentID := h.groupToEdit.ParentID.Int64
+	if parentID == h.groupToEdit.ID {
+		return errors.NewNetworkError(codes.InvalidArgument, "group can't be its own parent").
+			ToGRPCError()
+	}
+	if _, err := groupQuery.GetGroup(h.ctx, parentID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	ancestors, err := groupQuery.GetAncestorIDs(h.ctx, parentID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if slices.Contains(ancestors, h.groupToEdit.ID) {
+		return errors.NewNetworkError(codes.InvalidArgument, "parent_group_id can't be a subgroup of the group").
+			ToGRPCError()
+	}
+	return nil
+}
+
+func (h *editGroupHandler) response() *desc.EditGroupResponse {
+	return &desc.EditGroupResponse{
+		Group: newDescGroup(h.editedGroup),
+	}
+}
+
+type editGroupHandler struct {
+	ctx         context.Context
+	dao         dao.DAO
+	fields      []string
+	groupToEdit dao.GroupTable
+
+	editedGroup dao.GroupMembersTable
+}
+
+func newEditGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.EditGroupRequest,
+) (*editGroupHandler, error) {
+	h := &editGroupHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *editGroupHandler) adapt(req *desc.EditGroupRequest) *editGroupHandler {
+	h.groupToEdit.ID = req.GetGroupId()
+
+	if req.Description != nil {
+		h.groupToEdit.Description = nulltypes.NewNullString(req.Description)
+		h.fields = append(h.fields, "description")
+	}
+	if req.GetClearParent() {
+		h.groupToEdit.ParentID = sql.NullInt64{}
+		h.fields = append(h.fields, "parent_id")
+	} else if req.ParentGroupId != nil {
+		h.groupToEdit.ParentID = nulltypes.NewNullInt64(req.ParentGroupId)
+		h.fields = append(h.fields, "parent_id")
+	}
+	if req.Archived != nil {
+		h.groupToEdit.Archived = req.GetArchived()
+		h.fields = append(h.fields, "archived")
+	}
+	return h
+}
+
+func (h *editGroupHandler) validate() error {
+	if h.groupToEdit.ID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	if len(h.fields) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "nothing to edit").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) EditTopic(
+	ctx context.Context,
+	req *desc.EditTopicRequest,
+) (*desc.EditTopicResponse, error) {
+	h, err := newEditTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *editTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		topicQuery := tx.NewTopicQuery()
+
+		before, err := topicQuery.GetTopic(h.ctx, h.topicToEdit.ID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		topic, err := topicQuery.ChangeTopic(h.ctx, h.topicToEdit, h.fields...)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.editedTopic = topic
+
+		err = audit.Record(h.ctx, tx, audit.TargetTopic, []int64{topic.ID}, before, topic)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *editTopicHandler) response() *desc.EditTopicResponse {
+	var description *string
+	if h.editedTopic.Description.Valid {
+		description = &h.editedTopic.Description.String
+	}
+	return &desc.EditTopicResponse{
+		Topic: &desc.Topic{
+			TopicId:     h.editedTopic.ID,
+			Name:        h.editedTopic.Name,
+			Description: description,
+		},
+	}
+}
+
+type editTopicHandler struct {
+	ctx         context.Context
+	dao         dao.DAO
+	fields      []string
+	topicToEdit dao.TopicTable
+
+	editedTopic dao.TopicTable
+}
+
+func newEditTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.EditTopicRequest,
+) (*editTopicHandler, error) {
+	h := &editTopicHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *editTopicHandler) adapt(req *desc.EditTopicRequest) *editTopicHandler {
+	h.topicToEdit.ID = req.GetTopicId()
+
+	if req.Name != nil {
+		h.topicToEdit.Name = req.GetName()
+		h.fields = append(h.fields, "name")
+	}
+	if req.Description != nil {
+		h.topicToEdit.Description = nulltypes.NewNullString(req.Description)
+		h.fields = append(h.fields, "description")
+	}
+	return h
+}
+
+func (h *editTopicHandler) validate() error {
+	if h.topicToEdit.ID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be specified").
+			ToGRPCError()
+	}
+	if len(h.fields) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "nothing to edit").
+			ToGRPCError()
+	}
+	for _, field := range h.fields {
+		if field == "name" && h.topicToEdit.Name == "" {
+			return errors.NewNetworkError(codes.InvalidArgument, "name must not be empty").
+				ToGRPCError()
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
 	"telegram-notification-api/internal/types/nulltypes"
@@ -32,35 +33,43 @@ func (h *editUserHandler) handle() error {
 		return fmt.Errorf("got nil receiver")
 	}
 
-	user, err := h.dao.NewUserQuery().ChangeUser(h.ctx, h.userToEdit, h.fields...)
-	if err != nil {
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+
+		before, err := userQuery.GetUser(h.ctx, h.userToEdit.Id)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		if len(h.fields) > 0 {
+			if _, err := userQuery.ChangeUser(h.ctx, h.userToEdit, h.fields...); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+		}
+		if h.group != nil {
+			groupQuery := tx.NewGroupQuery()
+			if err := groupQuery.RemoveAllMemberships(h.ctx, h.userToEdit.Id); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			if err := addUserToGroups(h.ctx, groupQuery, h.userToEdit.Id, []string{*h.group}); err != nil {
+				return err
+			}
+		}
+
+		user, err := userQuery.GetUser(h.ctx, h.userToEdit.Id)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.editedUser = user
+
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{user.Id}, before, user)
 		return errors.WrapToNetwork(err).ToGRPCError()
-	}
-	h.editedUser = user
-	return nil
+	})
 }
 
 func (h *editUserHandler) response() *desc.EditUserResponse {
-	var patronymic *string
-	if h.editedUser.Patronymic.Valid {
-		patronymic = &h.editedUser.Patronymic.String
-	}
-
 	return &desc.EditUserResponse{
-		User: &desc.User{
-			UserId:                 h.editedUser.Id,
-			TelegramId:             h.editedUser.TelegramId,
-			UserRole:               desc.UserRole(desc.UserRole_value[h.editedUser.Role]),
-			UserNotificationStatus: desc.UserNotificationStatus(desc.UserNotificationStatus_value[h.editedUser.NotificationStatus]),
-			Group:                  h.editedUser.Group,
-			Fio: &desc.FIO{
-				Firstname:  h.editedUser.Firstname,
-				Surname:    h.editedUser.Surname,
-				Patronymic: patronymic,
-			},
-			MobilePhone: h.editedUser.MobilePhone,
-			UserStatus:  desc.UserStatus(desc.UserNotificationStatus_value[h.editedUser.Status]),
-		},
+		User: newDescUser(h.editedUser),
 	}
 }
 
@@ -69,6 +78,7 @@ type editUserHandler struct {
 	dao        dao.DAO
 	fields     []string
 	userToEdit dao.UserTable
+	group      *string
 
 	editedUser dao.UserTable
 }
@@ -105,8 +115,7 @@ func (h *editUserHandler) adapt(req *desc.EditUserRequest) *editUserHandler {
 		h.fields = append(h.fields, "telegram_id")
 	}
 	if req.Group != nil {
-		h.userToEdit.Group = req.GetGroup()
-		h.fields = append(h.fields, "user_group")
+		h.group = req.Group
 	}
 	if req.Firstname != nil {
 		h.userToEdit.Firstname = req.GetFirstname()
@@ -139,6 +148,16 @@ func (h *editUserHandler) validate() error {
 			NewNetworkError(codes.InvalidArgument, "telegram_id must be specified").
 			ToGRPCError()
 	}
+	if h.group != nil && *h.group == "" {
+		return errors.
+			NewNetworkError(codes.InvalidArgument, "group must not be empty").
+			ToGRPCError()
+	}
+	if len(h.fields) == 0 && h.group == nil {
+		return errors.
+			NewNetworkError(codes.InvalidArgument, "nothing to edit").
+			ToGRPCError()
+	}
 
 	return nil
 }
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) EditWebhook(
+	ctx context.Context,
+	req *desc.EditWebhookRequest,
+) (*desc.EditWebhookResponse, error) {
+	h, err := newEditWebhookHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *editWebhookHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		webhookQuery := tx.NewWebhookQuery()
+
+		before, err := webhookQuery.GetWebhook(h.ctx, h.webhookToEdit.ID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		webhook, err := webhookQuery.ChangeWebhook(h.ctx, h.webhookToEdit, h.fields...)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.editedWebhook = webhook
+
+		err = audit.Record(h.ctx, tx, audit.TargetWebhook, []int64{webhook.ID}, before, webhook)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *editWebhookHandler) response() *desc.EditWebhookResponse {
+	return &desc.EditWebhookResponse{
+		Webhook: newDescWebhook(h.editedWebhook),
+	}
+}
+
+type editWebhookHandler struct {
+	ctx           context.Context
+	dao           dao.DAO
+	fields        []string
+	webhookToEdit dao.WebhookTable
+
+	editedWebhook dao.WebhookTable
+}
+
+func newEditWebhookHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.EditWebhookRequest,
+) (*editWebhookHandler, error) {
+	h := &editWebhookHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *editWebhookHandler) adapt(req *desc.EditWebhookRequest) *editWebhookHandler {
+	h.webhookToEdit.ID = req.GetWebhookId()
+
+	if req.Url != nil {
+		h.webhookToEdit.URL = req.GetUrl()
+		h.fields = append(h.fields, "url")
+	}
+	if len(req.GetEventTypes()) > 0 {
+		h.webhookToEdit.EventTypes = distinctEventTypes(req.GetEventTypes())
+		h.fields = append(h.fields, "event_types")
+	}
+	if req.Secret != nil {
+		h.webhookToEdit.Secret = req.GetSecret()
+		h.fields = append(h.fields, "secret")
+	}
+	if req.Active != nil {
+		h.webhookToEdit.Active = req.GetActive()
+		h.fields = append(h.fields, "active")
+	}
+	return h
+}
+
+func (h *editWebhookHandler) validate() error {
+	if h.webhookToEdit.ID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "webhook_id must be specified").
+			ToGRPCError()
+	}
+	if len(h.fields) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "nothing to edit").
+			ToGRPCError()
+	}
+	for _, field := range h.fields {
+		switch field {
+		case "url":
+			if err := validateWebhookURL(h.webhookToEdit.URL); err != nil {
+				return err
+			}
+		case "event_types":
+			if err := validateWebhookEventTypes(h.webhookToEdit.EventTypes); err != nil {
+				return err
+			}
+		case "secret":
+			if h.webhookToEdit.Secret == "" {
+				return errors.NewNetworkError(codes.InvalidArgument, "secret must not be empty").
+					ToGRPCError()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+// erasedMessage replaces bodies of erased notifications
+const erasedMessage = "[erased]"
+
+func (s *server) EraseUserData(
+	ctx context.Context,
+	req *desc.EraseUserDataRequest,
+) (*desc.EraseUserDataResponse, error) {
+	h, err := newEraseUserDataHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *eraseUserDataHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		user, err := tx.NewUserQuery().GetUserIncludingDeleted(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		// erasing is repeatable, e.g. after notifications were sent to a purged user
+		if !user.PurgedAt.Valid {
+			if err = purgeUser(h.ctx, tx, h.userID); err != nil {
+				return err
+			}
+		}
+
+		notificationIDs, err := tx.NewNotificationQuery().EraseMessages(h.ctx, h.userID, erasedMessage)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.erasedNotificationIDs = notificationIDs
+		if len(notificationIDs) > 0 {
+			err = tx.NewAuditLogQuery().RedactChanges(
+				h.ctx,
+				audit.TargetNotification,
+				notificationIDs,
+				[]string{"message", "media_content"},
+			)
+			if err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+		}
+
+		erased := map[string]interface{}{
+			"erased":                    true,
+			"erased_notification_count": len(notificationIDs),
+		}
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{h.userID}, nil, erased)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *eraseUserDataHandler) response() *desc.EraseUserDataResponse {
+	return &desc.EraseUserDataResponse{
+		ErasedNotificationCount: int64(len(h.erasedNotificationIDs)),
+	}
+}
+
+type eraseUserDataHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID int64
+
+	erasedNotificationIDs []int64
+}
+
+func newEraseUserDataHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.EraseUserDataRequest,
+) (*eraseUserDataHandler, error) {
+	h := &eraseUserDataHandler{
+		ctx:    ctx,
+		dao:    dao,
+		userID: req.GetUserId(),
+	}
+	return h, h.validate()
+}
+
+func (h *eraseUserDataHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
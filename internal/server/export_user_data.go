@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) ExportUserData(
+	ctx context.Context,
+	req *desc.ExportUserDataRequest,
+) (*desc.ExportUserDataResponse, error) {
+	h, err := newExportUserDataHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+// userDataExport is the document returned by ExportUserData
+type userDataExport struct {
+	ExportedAt            time.Time              `json:"exported_at"`
+	Profile               userDataProfile        `json:"profile"`
+	Groups                []string               `json:"groups"`
+	ModeratedGroupIDs     []int64                `json:"moderated_group_ids"`
+	Topics                []userDataTopic        `json:"topics"`
+	ApiClients            []userDataApiClient    `json:"api_clients"`
+	NotificationsReceived []userDataNotification `json:"notifications_received"`
+	NotificationsSent     []userDataNotification `json:"notifications_sent"`
+	AuditEntries          []userDataAuditEntry   `json:"audit_entries"`
+}
+
+type userDataProfile struct {
+	UserID             int64      `json:"user_id"`
+	TelegramID         int64      `json:"telegram_id"`
+	Role               string     `json:"role"`
+	NotificationStatus string     `json:"notification_status"`
+	Firstname          string     `json:"firstname"`
+	Surname            string     `json:"surname"`
+	Patronymic         *string    `json:"patronymic"`
+	MobilePhone        string     `json:"mobile_phone"`
+	Status             string     `json:"status"`
+	DeletedAt          *time.Time `json:"deleted_at"`
+	PurgedAt           *time.Time `json:"purged_at"`
+}
+
+type userDataTopic struct {
+	TopicID int64  `json:"topic_id"`
+	Name    string `json:"name"`
+}
+
+type userDataApiClient struct {
+	ApiClientID int64     `json:"api_client_id"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type userDataNotification struct {
+	NotificationID int64 `json:"notification_id"`
+	SenderID       int64 `json:"sender_id"`
+	// ReceiverIDs are exported only for sent notifications
+	ReceiverIDs    []int64   `json:"receiver_ids,omitempty"`
+	Message        string    `json:"message"`
+	MediaContent   *string   `json:"media_content"`
+	TopicID        *int64    `json:"topic_id"`
+	DeliveryStatus string    `json:"delivery_status"`
+	Date           time.Time `json:"date"`
+}
+
+type userDataAuditEntry struct {
+	AuditRecordID int64           `json:"audit_record_id"`
+	ActorID       *int64          `json:"actor_id"`
+	Method        string          `json:"method"`
+	TargetType    string          `json:"target_type"`
+	TargetIDs     []int64         `json:"target_ids"`
+	Changes       json.RawMessage `json:"changes"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func (h *exportUserDataHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	// everything is read in one transaction to get a consistent snapshot
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		user, err := tx.NewUserQuery().GetUserIncludingDeleted(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.export.Profile = newUserDataProfile(user)
+		h.export.Groups = user.Groups
+
+		if h.export.ModeratedGroupIDs, err = tx.NewGroupQuery().GetModeratedGroupIDs(h.ctx, h.userID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		topics, err := tx.NewTopicQuery().GetSubscriptions(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, topic := range topics {
+			h.export.Topics = append(h.export.Topics, userDataTopic{TopicID: topic.ID, Name: topic.Name})
+		}
+
+		apiClients, err := tx.NewApiClientQuery().GetApiClientsByUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, apiClient := range apiClients {
+			h.export.ApiClients = append(h.export.ApiClients, userDataApiClient{
+				ApiClientID: apiClient.ID,
+				Name:        apiClient.Name,
+				CreatedAt:   apiClient.CreatedAt,
+			})
+		}
+
+		notificationQuery := tx.NewNotificationQuery()
+		received, err := notificationQuery.GetNotificationsByReceiver(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, notification := range received {
+			// other receivers are not data of the user
+			notification.ReceiverIDs = nil
+			h.export.NotificationsReceived = append(h.export.NotificationsReceived, newUserDataNotification(notification))
+		}
+		sent, err := notificationQuery.GetNotificationsBySender(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, notification := range sent {
+			h.export.NotificationsSent = append(h.export.NotificationsSent, newUserDataNotification(notification))
+		}
+
+		auditQuery := tx.NewAuditLogQuery()
+		targetRecords, err := auditQuery.GetAuditRecords(h.ctx, dao.AuditLogFilter{
+			TargetType: audit.TargetUser,
+			TargetID:   sql.NullInt64{Int64: h.userID, Valid: true},
+		}, nil, 0, 0)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		actorRecords, err := auditQuery.GetAuditRecords(h.ctx, dao.AuditLogFilter{
+			ActorID: sql.NullInt64{Int64: h.userID, Valid: true},
+		}, nil, 0, 0)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		seen := make(map[int64]struct{})
+		for _, record := range append(targetRecords, actorRecords...) {
+			if _, ok := seen[record.ID]; ok {
+				continue
+			}
+			seen[record.ID] = struct{}{}
+			h.export.AuditEntries = append(h.export.AuditEntries, newUserDataAuditEntry(record))
+		}
+
+		h.export.ExportedAt = time.Now().UTC()
+		return nil
+	})
+}
+
+func (h *exportUserDataHandler) response() *desc.ExportUserDataResponse {
+	document, err := json.Marshal(h.export)
+	if err != nil {
+		// all the fields of the document are serializable
+		panic(err)
+	}
+	return &desc.ExportUserDataResponse{
+		Document: string(document),
+	}
+}
+
+func newUserDataProfile(user dao.UserTable) userDataProfile {
+	profile := userDataProfile{
+		UserID:             user.Id,
+		TelegramID:         user.TelegramId,
+		Role:               user.Role,
+		NotificationStatus: user.NotificationStatus,
+		Firstname:          user.Firstname,
+		Surname:            user.Surname,
+		MobilePhone:        user.MobilePhone,
+		Status:             user.Status,
+	}
+	if user.Patronymic.Valid {
+		profile.Patronymic = &user.Patronymic.String
+	}
+	if user.DeletedAt.Valid {
+		profile.DeletedAt = &user.DeletedAt.Time
+	}
+	if user.PurgedAt.Valid {
+		profile.PurgedAt = &user.PurgedAt.Time
+	}
+	return profile
+}
+
+func newUserDataNotification(notification dao.NotificationTable) userDataNotification {
+	n := userDataNotification{
+		NotificationID: notification.ID,
+		SenderID:       notification.SenderID,
+		ReceiverIDs:    notification.ReceiverIDs,
+		Message:        notification.Message,
+		DeliveryStatus: notification.Status,
+		Date:           notification.Date,
+	}
+	if notification.MediaContent.Valid {
+		n.MediaContent = &notification.MediaContent.String
+	}
+	if notification.TopicID.Valid {
+		n.TopicID = &notification.TopicID.Int64
+	}
+	return n
+}
+
+func newUserDataAuditEntry(record dao.AuditLogTable) userDataAuditEntry {
+	entry := userDataAuditEntry{
+		AuditRecordID: record.ID,
+		Method:        record.Method,
+		TargetType:    record.TargetType,
+		TargetIDs:     record.TargetIDs,
+		Changes:       json.RawMessage(record.Changes),
+		CreatedAt:     record.CreatedAt,
+	}
+	if record.ActorID.Valid {
+		entry.ActorID = &record.ActorID.Int64
+	}
+	return entry
+}
+
+type exportUserDataHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID int64
+
+	export userDataExport
+}
+
+func newExportUserDataHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.ExportUserDataRequest,
+) (*exportUserDataHandler, error) {
+	h := &exportUserDataHandler{
+		ctx:    ctx,
+		dao:    dao,
+		userID: req.GetUserId(),
+	}
+	return h, h.validate()
+}
+
+func (h *exportUserDataHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
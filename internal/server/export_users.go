@@ -0,0 +1,231 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"slices"
+	"strconv"
+	"strings"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+const (
+	// exportPageSize is the number of users read from the database at once
+	exportPageSize = 500
+	// exportChunkSize is the size a chunk is sent after, it's far below the grpc message limit
+	exportChunkSize = 64 * 1024
+)
+
+// exportColumns are columns ExportUsers can write, in the default order
+var exportColumns = []string{
+	"user_id",
+	"telegram_id",
+	"user_role",
+	"user_notification_status",
+	"groups",
+	"firstname",
+	"surname",
+	"patronymic",
+	"mobile_phone",
+	"user_status",
+	"deleted_at",
+}
+
+func (s *server) ExportUsers(
+	req *desc.ExportUsersRequest,
+	stream desc.TelegramNotificationService_ExportUsersServer,
+) error {
+	h, err := newExportUsersHandler(stream.Context(), s.dao, stream, req)
+	if err != nil {
+		return err
+	}
+	return h.handle()
+}
+
+func (h *exportUsersHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	var csvWriter *csv.Writer
+	if h.format == desc.ExportFormat_CSV {
+		csvWriter = csv.NewWriter(&h.buf)
+		if err := csvWriter.Write(h.columns); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+
+	userQuery := h.dao.NewUserQuery()
+	var keyset dao.Keyset
+	for {
+		users, err := userQuery.GetUsersByFilterAfter(h.ctx, h.filter, keyset, exportPageSize)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		for _, user := range users {
+			if err = h.writeUser(csvWriter, user); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			if h.buf.Len() >= exportChunkSize {
+				if err = h.send(); err != nil {
+					return err
+				}
+			}
+		}
+		if len(users) < exportPageSize {
+			break
+		}
+		keyset.AfterID = users[len(users)-1].Id
+	}
+	return h.send()
+}
+
+func (h *exportUsersHandler) writeUser(csvWriter *csv.Writer, user dao.UserTable) error {
+	if h.format == desc.ExportFormat_CSV {
+		record := make([]string, 0, len(h.columns))
+		for _, column := range h.columns {
+			record = append(record, h.csvValue(user, column))
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	}
+
+	object := make(map[string]interface{}, len(h.columns))
+	for _, column := range h.columns {
+		object[column] = exportValue(user, column)
+	}
+	line, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	h.buf.Write(line)
+	h.buf.WriteByte('\n')
+	return nil
+}
+
+// send sends buffered rows as a chunk
+func (h *exportUsersHandler) send() error {
+	if h.buf.Len() == 0 {
+		return nil
+	}
+	if err := h.stream.Send(&desc.ExportUsersResponse{Chunk: h.buf.Bytes()}); err != nil {
+		return err
+	}
+	h.buf.Reset()
+	return nil
+}
+
+func (h *exportUsersHandler) csvValue(user dao.UserTable, column string) string {
+	switch value := exportValue(user, column).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case []string:
+		return strings.Join(value, h.groupsSeparator)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// exportValue returns a value of the column, nil for absent values
+func exportValue(user dao.UserTable, column string) interface{} {
+	switch column {
+	case "user_id":
+		return user.Id
+	case "telegram_id":
+		return user.TelegramId
+	case "user_role":
+		return user.Role
+	case "user_notification_status":
+		return user.NotificationStatus
+	case "groups":
+		return []string(user.Groups)
+	case "firstname":
+		return user.Firstname
+	case "surname":
+		return user.Surname
+	case "patronymic":
+		if user.Patronymic.Valid {
+			return user.Patronymic.String
+		}
+	case "mobile_phone":
+		return user.MobilePhone
+	case "user_status":
+		return user.Status
+	case "deleted_at":
+		if user.DeletedAt.Valid {
+			return user.DeletedAt.Time.UTC().Format(time.RFC3339)
+		}
+	}
+	return nil
+}
+
+type exportUsersHandler struct {
+	ctx    context.Context
+	dao    dao.DAO
+	stream desc.TelegramNotificationService_ExportUsersServer
+
+	filter          dao.UserFilter
+	format          desc.ExportFormat
+	columns         []string
+	groupsSeparator string
+
+	buf bytes.Buffer
+}
+
+func newExportUsersHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	stream desc.TelegramNotificationService_ExportUsersServer,
+	req *desc.ExportUsersRequest,
+) (*exportUsersHandler, error) {
+	h := &exportUsersHandler{
+		ctx:    ctx,
+		dao:    dao,
+		stream: stream,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *exportUsersHandler) adapt(req *desc.ExportUsersRequest) *exportUsersHandler {
+	h.filter = newUserFilter(req.GetFilter())
+	h.format = req.GetFormat()
+	h.columns = req.GetColumns()
+	if len(h.columns) == 0 {
+		h.columns = exportColumns
+	}
+	h.groupsSeparator = req.GetGroupsSeparator()
+	if h.groupsSeparator == "" {
+		h.groupsSeparator = importDefaultGroupsSeparator
+	}
+	return h
+}
+
+func (h *exportUsersHandler) validate() error {
+	if _, ok := desc.ExportFormat_name[int32(h.format)]; !ok {
+		return errors.NewNetworkError(codes.InvalidArgument, "unknown format").
+			ToGRPCError()
+	}
+	for _, column := range h.columns {
+		if !slices.Contains(exportColumns, column) {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("unknown column %q", column)).
+				ToGRPCError()
+		}
+	}
+	return validateUserFilter(h.filter)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetApiClient(
+	ctx context.Context,
+	req *desc.GetApiClientRequest,
+) (*desc.GetApiClientResponse, error) {
+	h, err := newGetApiClientHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getApiClientHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	apiClient, err := h.dao.NewApiClientQuery().GetApiClient(h.ctx, h.apiClientID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.apiClient = apiClient
+	return nil
+}
+
+func (h *getApiClientHandler) response() *desc.GetApiClientResponse {
+	return &desc.GetApiClientResponse{
+		ApiClient: newDescApiClient(h.apiClient),
+	}
+}
+
+type getApiClientHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	apiClientID int64
+
+	apiClient dao.ApiClientTable
+}
+
+func newGetApiClientHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetApiClientRequest,
+) (*getApiClientHandler, error) {
+	h := &getApiClientHandler{
+		ctx:         ctx,
+		dao:         dao,
+		apiClientID: req.GetApiClientId(),
+	}
+	return h, h.validate()
+}
+
+func (h *getApiClientHandler) validate() error {
+	if h.apiClientID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "api_client_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
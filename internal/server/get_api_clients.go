@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetApiClients(
+	ctx context.Context,
+	req *desc.GetApiClientsRequest,
+) (*desc.GetApiClientsResponse, error) {
+	h, err := newGetApiClientsHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getApiClientsHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	apiClientQuery := h.dao.NewApiClientQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		apiClients, err := apiClientQuery.GetApiClientsAfter(h.ctx, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.apiClients, h.nextPageToken, err = nextPage(h.paginator, h.req, apiClients, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		apiClients, err := apiClientQuery.GetApiClients(h.ctx, newOrderBy(h.orderBy, apiClientOrderFields), h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.apiClients = apiClients
+	}
+
+	totalCount, err := apiClientQuery.CountApiClients(h.ctx)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *getApiClientsHandler) response() *desc.GetApiClientsResponse {
+	apiClients := make([]*desc.ApiClient, 0, len(h.apiClients))
+	for idx := range h.apiClients {
+		apiClients = append(apiClients, newDescApiClient(h.apiClients[idx]))
+	}
+
+	return &desc.GetApiClientsResponse{
+		ApiClients:    apiClients,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(apiClients)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type getApiClientsHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetApiClientsRequest
+
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
+
+	apiClients    []dao.ApiClientTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetApiClientsHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetApiClientsRequest,
+) (*getApiClientsHandler, error) {
+	h := &getApiClientsHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getApiClientsHandler) adapt(req *desc.GetApiClientsRequest) *getApiClientsHandler {
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		apiClientOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
+	return h
+}
+
+func (h *getApiClientsHandler) validate() error {
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	if err := validateOrderBy(h.orderBy, apiClientOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
+}
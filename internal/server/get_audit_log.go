@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetAuditLog(
+	ctx context.Context,
+	req *desc.GetAuditLogRequest,
+) (*desc.GetAuditLogResponse, error) {
+	h, err := newGetAuditLogHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response()
+}
+
+func (h *getAuditLogHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	auditQuery := h.dao.NewAuditLogQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		records, err := auditQuery.GetAuditRecordsAfter(h.ctx, h.filter, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.records, h.nextPageToken, err = nextPage(h.paginator, h.req, records, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		records, err := auditQuery.GetAuditRecords(
+			h.ctx,
+			h.filter,
+			newOrderBy(h.orderBy, auditRecordOrderFields),
+			h.limit,
+			h.offset,
+		)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.records = records
+	}
+
+	totalCount, err := auditQuery.CountAuditRecords(h.ctx, h.filter)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *getAuditLogHandler) response() (*desc.GetAuditLogResponse, error) {
+	records := make([]*desc.AuditRecord, 0, len(h.records))
+	for idx := range h.records {
+		record, err := newDescAuditRecord(h.records[idx])
+		if err != nil {
+			return nil, errors.WrapToNetwork(err).ToGRPCError()
+		}
+		records = append(records, record)
+	}
+
+	return &desc.GetAuditLogResponse{
+		Records:       records,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(records)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}, nil
+}
+
+func newDescAuditRecord(record dao.AuditLogTable) (*desc.AuditRecord, error) {
+	var changes map[string]audit.Change
+	if err := json.Unmarshal(record.Changes, &changes); err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	descChanges := make([]*desc.FieldChange, 0, len(fields))
+	for _, field := range fields {
+		before, err := structpb.NewValue(changes[field].Before)
+		if err != nil {
+			return nil, err
+		}
+		after, err := structpb.NewValue(changes[field].After)
+		if err != nil {
+			return nil, err
+		}
+		descChanges = append(descChanges, &desc.FieldChange{
+			Field:  field,
+			Before: before,
+			After:  after,
+		})
+	}
+
+	descRecord := &desc.AuditRecord{
+		AuditRecordId: record.ID,
+		Method:        record.Method,
+		TargetType:    record.TargetType,
+		TargetIds:     record.TargetIDs,
+		Changes:       descChanges,
+		RequestId:     record.RequestID,
+		CreatedAt:     timestamppb.New(record.CreatedAt),
+	}
+	if record.ActorID.Valid {
+		descRecord.ActorId = &record.ActorID.Int64
+	}
+	if record.ApiClientID.Valid {
+		descRecord.ApiClientId = &record.ApiClientID.Int64
+	}
+	return descRecord, nil
+}
+
+type getAuditLogHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetAuditLogRequest
+
+	filter  dao.AuditLogFilter
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
+
+	records       []dao.AuditLogTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetAuditLogHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetAuditLogRequest,
+) (*getAuditLogHandler, error) {
+	h := &getAuditLogHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getAuditLogHandler) adapt(req *desc.GetAuditLogRequest) *getAuditLogHandler {
+	h.filter = dao.AuditLogFilter{
+		ActorID:    nulltypes.NewNullInt64(req.ActorId),
+		Method:     req.GetMethod(),
+		TargetType: req.GetTargetType(),
+		TargetID:   nulltypes.NewNullInt64(req.TargetId),
+	}
+	if req.From != nil {
+		h.filter.From = sql.NullTime{Time: req.GetFrom().AsTime(), Valid: true}
+	}
+	if req.To != nil {
+		h.filter.To = sql.NullTime{Time: req.GetTo().AsTime(), Valid: true}
+	}
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	// records are listed from the newest by default
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		auditRecordOrderFields,
+		dao.OrderBy{Column: "id", Desc: true},
+	)
+	return h
+}
+
+func (h *getAuditLogHandler) validate() error {
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	if h.filter.From.Valid && h.filter.To.Valid && !h.filter.From.Time.Before(h.filter.To.Time) {
+		return errors.NewNetworkError(codes.InvalidArgument, "from must be before to").
+			ToGRPCError()
+	}
+	if err := validateOrderBy(h.orderBy, auditRecordOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/ratelimit"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetClientUsage(
+	ctx context.Context,
+	req *desc.GetClientUsageRequest,
+) (*desc.GetClientUsageResponse, error) {
+	h, err := newGetClientUsageHandler(ctx, s.dao, s.limiter, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getClientUsageHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if h.client != ratelimit.ClientFromContext(h.ctx) {
+		if err := authz.RequireRole(h.ctx, h.dao, h.req, desc.UserRole_ADMIN); err != nil {
+			return err
+		}
+	}
+
+	usage, err := h.limiter.Usage(h.ctx, h.client)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.usage = usage
+	return nil
+}
+
+func (h *getClientUsageHandler) response() *desc.GetClientUsageResponse {
+	rateLimits := make([]*desc.MethodRateLimit, 0, len(h.usage.Limits))
+	for _, limit := range h.usage.Limits {
+		rateLimits = append(rateLimits, &desc.MethodRateLimit{
+			Method:            limit.Method,
+			RequestsPerSecond: limit.RequestsPerSecond,
+			Burst:             int64(limit.Burst),
+			AvailableRequests: limit.Available,
+		})
+	}
+
+	var remaining int64
+	if h.usage.DailyMessageQuota > 0 {
+		remaining = max(h.usage.DailyMessageQuota-h.usage.MessagesSent, 0)
+	}
+	return &desc.GetClientUsageResponse{
+		Client:            h.usage.Client,
+		Day:               timestamppb.New(h.usage.Day),
+		MessagesSent:      h.usage.MessagesSent,
+		DailyMessageQuota: h.usage.DailyMessageQuota,
+		RemainingMessages: remaining,
+		RateLimits:        rateLimits,
+	}
+}
+
+type getClientUsageHandler struct {
+	ctx     context.Context
+	dao     dao.DAO
+	limiter *ratelimit.Limiter
+	req     *desc.GetClientUsageRequest
+
+	client string
+
+	usage ratelimit.Usage
+}
+
+func newGetClientUsageHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	limiter *ratelimit.Limiter,
+	req *desc.GetClientUsageRequest,
+) (*getClientUsageHandler, error) {
+	h := &getClientUsageHandler{
+		ctx:     ctx,
+		dao:     dao,
+		limiter: limiter,
+		req:     req,
+	}
+	return h.adapt(req), nil
+}
+
+func (h *getClientUsageHandler) adapt(req *desc.GetClientUsageRequest) *getClientUsageHandler {
+	h.client = ratelimit.ClientFromContext(h.ctx)
+	if req.Client != nil {
+		h.client = req.GetClient()
+	}
+	return h
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetGroup(
+	ctx context.Context,
+	req *desc.GetGroupRequest,
+) (*desc.GetGroupResponse, error) {
+	h, err := newGetGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	group, err := h.dao.NewGroupQuery().GetGroup(h.ctx, h.groupID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.group = group
+	return nil
+}
+
+func (h *getGroupHandler) response() *desc.GetGroupResponse {
+	return &desc.GetGroupResponse{
+		Group: newDescGroup(h.group),
+	}
+}
+
+type getGroupHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	groupID int64
+
+	group dao.GroupMembersTable
+}
+
+func newGetGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetGroupRequest,
+) (*getGroupHandler, error) {
+	h := &getGroupHandler{
+		ctx:     ctx,
+		dao:     dao,
+		groupID: req.GetGroupId(),
+	}
+	return h, h.validate()
+}
+
+func (h *getGroupHandler) validate() error {
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetGroupTree(
+	ctx context.Context,
+	req *desc.GetGroupTreeRequest,
+) (*desc.GetGroupTreeResponse, error) {
+	h, err := newGetGroupTreeHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getGroupTreeHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	groupQuery := h.dao.NewGroupQuery()
+
+	if h.rootID != nil {
+		if _, err := groupQuery.GetGroup(h.ctx, *h.rootID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+
+	groups, err := groupQuery.GetGroups(h.ctx, h.includeArchived)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	counts, err := groupQuery.GetSubtreeMemberCounts(h.ctx)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	h.groups = groups
+	h.subtreeMemberCounts = make(map[int64]int64, len(counts))
+	for _, count := range counts {
+		h.subtreeMemberCounts[count.GroupID] = count.MemberCount
+	}
+	return nil
+}
+
+func (h *getGroupTreeHandler) response() *desc.GetGroupTreeResponse {
+	nodes := make(map[int64]*desc.GroupTreeNode, len(h.groups))
+	for idx := range h.groups {
+		nodes[h.groups[idx].ID] = &desc.GroupTreeNode{
+			Group:            newDescGroup(h.groups[idx]),
+			TotalMemberCount: h.subtreeMemberCounts[h.groups[idx].ID],
+		}
+	}
+
+	roots := make([]*desc.GroupTreeNode, 0)
+	// groups are ordered by name, so children are ordered by name too
+	for idx := range h.groups {
+		node := nodes[h.groups[idx].ID]
+		if h.rootID != nil && h.groups[idx].ID == *h.rootID {
+			roots = append(roots, node)
+			continue
+		}
+		if !h.groups[idx].ParentID.Valid {
+			if h.rootID == nil {
+				roots = append(roots, node)
+			}
+			continue
+		}
+		// parent is absent when it is archived, so the whole subtree is hidden
+		if parent, ok := nodes[h.groups[idx].ParentID.Int64]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return &desc.GetGroupTreeResponse{
+		Roots: roots,
+	}
+}
+
+type getGroupTreeHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	rootID          *int64
+	includeArchived bool
+
+	groups              []dao.GroupMembersTable
+	subtreeMemberCounts map[int64]int64
+}
+
+func newGetGroupTreeHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetGroupTreeRequest,
+) (*getGroupTreeHandler, error) {
+	h := &getGroupTreeHandler{
+		ctx:             ctx,
+		dao:             dao,
+		rootID:          req.RootGroupId,
+		includeArchived: req.GetIncludeArchived(),
+	}
+	return h, h.validate()
+}
+
+func (h *getGroupTreeHandler) validate() error {
+	if h.rootID != nil && *h.rootID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "root_group_id must be positive").
+			ToGRPCError()
+	}
+	return nil
+}
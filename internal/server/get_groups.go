@@ -10,9 +10,9 @@ import (
 
 func (s *server) GetGroups(
 	ctx context.Context,
-	_ *desc.GetGroupsRequest,
+	req *desc.GetGroupsRequest,
 ) (*desc.GetGroupsResponse, error) {
-	h, err := newGetGroupsHandler(ctx, s.dao)
+	h, err := newGetGroupsHandler(ctx, s.dao, req)
 	if err != nil {
 		return nil, err
 	}
@@ -22,14 +22,18 @@ func (s *server) GetGroups(
 
 func (h *getGroupsHandler) handle() error {
 	var err error
-	h.groups, err = h.dao.NewUserQuery().GetGroups(h.ctx)
+	h.groups, err = h.dao.NewGroupQuery().GetGroups(h.ctx, h.includeArchived)
 	return errors.WrapToNetwork(err).ToGRPCError()
 }
 
 func (h *getGroupsHandler) response() *desc.GetGroupsResponse {
+	groups := make([]*desc.Group, 0, len(h.groups))
+	for idx := range h.groups {
+		groups = append(groups, newDescGroup(h.groups[idx]))
+	}
 	return &desc.GetGroupsResponse{
-		Groups: h.groups,
-		Count:  int64(len(h.groups)),
+		Groups: groups,
+		Count:  int64(len(groups)),
 	}
 }
 
@@ -37,16 +41,20 @@ type getGroupsHandler struct {
 	ctx context.Context
 	dao dao.DAO
 
-	groups []string
+	includeArchived bool
+
+	groups []dao.GroupMembersTable
 }
 
 func newGetGroupsHandler(
 	ctx context.Context,
 	dao dao.DAO,
+	req *desc.GetGroupsRequest,
 ) (*getGroupsHandler, error) {
 	h := &getGroupsHandler{
-		ctx: ctx,
-		dao: dao,
+		ctx:             ctx,
+		dao:             dao,
+		includeArchived: req.GetIncludeArchived(),
 	}
 	return h, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/protobuf/types/known/timestamppb"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
 
@@ -27,20 +26,8 @@ func (s *server) GetNotification(
 }
 
 func (h *getNotificationHandler) response() *desc.GetNotificationResponse {
-	var mediaContent *string
-	if h.notification.MediaContent.Valid {
-		mediaContent = &h.notification.MediaContent.String
-	}
 	return &desc.GetNotificationResponse{
-		Notification: &desc.Notification{
-			NotificationId:     h.notification.ID,
-			SenderId:           h.notification.SenderID,
-			ReceiverIds:        h.notification.ReceiverIDs,
-			Message:            h.notification.Message,
-			MediaContent:       mediaContent,
-			NotificationStatus: desc.NotificationStatus(desc.NotificationStatus_value[h.notification.Status]),
-			Date:               timestamppb.New(h.notification.Date),
-		},
+		Notification: newDescNotification(h.notification),
 	}
 }
 
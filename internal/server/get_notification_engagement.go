@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+// maxEngagementNotifications limits the number of notifications of an engagement report
+const maxEngagementNotifications = 1000
+
+func (s *server) GetNotificationEngagement(
+	ctx context.Context,
+	req *desc.GetNotificationEngagementRequest,
+) (*desc.GetNotificationEngagementResponse, error) {
+	h, err := newGetNotificationEngagementHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getNotificationEngagementHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	linkQuery := h.dao.NewLinkQuery()
+	var err error
+	h.notifications, err = linkQuery.GetNotificationEngagement(h.ctx, h.notificationIDs)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.links, err = linkQuery.GetLinkEngagement(h.ctx, h.notificationIDs)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.groups, err = linkQuery.GetGroupEngagement(h.ctx, h.notificationIDs)
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+func (h *getNotificationEngagementHandler) response() *desc.GetNotificationEngagementResponse {
+	links := make(map[int64][]*desc.LinkEngagement)
+	for _, link := range h.links {
+		links[link.NotificationID] = append(links[link.NotificationID], &desc.LinkEngagement{
+			LinkId:  link.ID,
+			Url:     link.URL,
+			Clicked: link.Clicked,
+			Clicks:  link.Clicks,
+		})
+	}
+
+	notifications := make([]*desc.NotificationEngagement, 0, len(h.notifications))
+	for _, notification := range h.notifications {
+		notifications = append(notifications, &desc.NotificationEngagement{
+			NotificationId:   notification.NotificationID,
+			Delivered:        notification.Delivered,
+			Clicked:          notification.Clicked,
+			Clicks:           notification.Clicks,
+			ClickThroughRate: clickThroughRate(notification.Clicked, notification.Delivered),
+			Links:            links[notification.NotificationID],
+		})
+	}
+
+	groups := make([]*desc.GroupEngagement, 0, len(h.groups))
+	for _, group := range h.groups {
+		groups = append(groups, &desc.GroupEngagement{
+			GroupId:          group.GroupID,
+			Delivered:        group.Delivered,
+			Clicked:          group.Clicked,
+			ClickThroughRate: clickThroughRate(group.Clicked, group.Delivered),
+		})
+	}
+
+	return &desc.GetNotificationEngagementResponse{
+		Notifications: notifications,
+		Groups:        groups,
+	}
+}
+
+func clickThroughRate(clicked int64, delivered int64) float64 {
+	if delivered == 0 {
+		return 0
+	}
+	return float64(clicked) / float64(delivered)
+}
+
+type getNotificationEngagementHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	notificationIDs []int64
+
+	notifications []dao.NotificationEngagementTable
+	links         []dao.LinkEngagementTable
+	groups        []dao.GroupEngagementTable
+}
+
+func newGetNotificationEngagementHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetNotificationEngagementRequest,
+) (*getNotificationEngagementHandler, error) {
+	h := &getNotificationEngagementHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getNotificationEngagementHandler) adapt(
+	req *desc.GetNotificationEngagementRequest,
+) *getNotificationEngagementHandler {
+	h.notificationIDs = req.GetNotificationIds()
+	return h
+}
+
+func (h *getNotificationEngagementHandler) validate() error {
+	if len(h.notificationIDs) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "notification_ids must be specified").
+			ToGRPCError()
+	}
+	if len(h.notificationIDs) > maxEngagementNotifications {
+		return errors.NewNetworkError(
+			codes.InvalidArgument,
+			fmt.Sprintf("at most %d notification_ids are allowed", maxEngagementNotifications),
+		).ToGRPCError()
+	}
+	return nil
+}
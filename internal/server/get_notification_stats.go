@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetNotificationStats(
+	ctx context.Context,
+	req *desc.GetNotificationStatsRequest,
+) (*desc.GetNotificationStatsResponse, error) {
+	h, err := newGetNotificationStatsHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getNotificationStatsHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	periodGroupBy := dao.StatsByHour
+	if h.interval == desc.StatsInterval_DAY {
+		periodGroupBy = dao.StatsByDay
+	}
+	for _, groupBy := range []dao.StatsGroupBy{dao.StatsTotal, dao.StatsBySender, dao.StatsByGroup, periodGroupBy} {
+		rows, err := h.dao.NewDeliveryQuery().GetDeliveryStats(h.ctx, h.filter, groupBy)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.rows[groupBy] = rows
+	}
+	h.periodGroupBy = periodGroupBy
+	return nil
+}
+
+func (h *getNotificationStatsHandler) response() *desc.GetNotificationStatsResponse {
+	resp := &desc.GetNotificationStatsResponse{
+		Total: &desc.DeliveryStats{},
+	}
+	if total := newDeliveryStats(h.rows[dao.StatsTotal], statsKeyID); len(total) > 0 {
+		resp.Total = total[0].stats
+	}
+	for _, s := range newDeliveryStats(h.rows[dao.StatsBySender], statsKeyID) {
+		resp.BySender = append(resp.BySender, &desc.SenderStats{SenderId: s.key, Stats: s.stats})
+	}
+	for _, s := range newDeliveryStats(h.rows[dao.StatsByGroup], statsKeyID) {
+		resp.ByGroup = append(resp.ByGroup, &desc.GroupStats{GroupId: s.key, Stats: s.stats})
+	}
+	for _, s := range newDeliveryStats(h.rows[h.periodGroupBy], statsPeriod) {
+		resp.ByPeriod = append(resp.ByPeriod, &desc.PeriodStats{
+			PeriodStart: timestamppb.New(time.Unix(s.key, 0)),
+			Stats:       s.stats,
+		})
+	}
+	return resp
+}
+
+type keyedDeliveryStats struct {
+	key   int64
+	stats *desc.DeliveryStats
+}
+
+func statsKeyID(row dao.DeliveryStatsTable) int64 {
+	return row.KeyID.Int64
+}
+
+func statsPeriod(row dao.DeliveryStatsTable) int64 {
+	return row.Period.Time.Unix()
+}
+
+// newDeliveryStats merges rows of statuses into stats of keys, rows of a key must follow each other
+func newDeliveryStats(rows []dao.DeliveryStatsTable, key func(dao.DeliveryStatsTable) int64) []keyedDeliveryStats {
+	var result []keyedDeliveryStats
+	var latencySum float64
+	finish := func() {
+		if len(result) == 0 {
+			return
+		}
+		stats := result[len(result)-1].stats
+		if stats.Sent > 0 {
+			stats.AverageLatency = durationpb.New(time.Duration(latencySum / float64(stats.Sent) * float64(time.Second)))
+		}
+	}
+
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].key != key(row) {
+			finish()
+			result = append(result, keyedDeliveryStats{key: key(row), stats: &desc.DeliveryStats{}})
+			latencySum = 0
+		}
+		stats := result[len(result)-1].stats
+		stats.Total += row.Deliveries
+		switch row.Status {
+		case desc.DeliveryStatus_PENDING.String():
+			stats.Pending += row.Deliveries
+		case desc.DeliveryStatus_DELIVERED.String():
+			stats.Sent += row.Deliveries
+		case desc.DeliveryStatus_FAILED.String():
+			stats.Failed += row.Deliveries
+		case desc.DeliveryStatus_SKIPPED.String():
+			stats.Skipped += row.Deliveries
+		case desc.DeliveryStatus_BLOCKED.String():
+			stats.Blocked += row.Deliveries
+		}
+		latencySum += row.LatencySum
+	}
+	finish()
+	return result
+}
+
+type getNotificationStatsHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	filter   dao.DeliveryStatsFilter
+	interval desc.StatsInterval
+
+	rows          map[dao.StatsGroupBy][]dao.DeliveryStatsTable
+	periodGroupBy dao.StatsGroupBy
+}
+
+func newGetNotificationStatsHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetNotificationStatsRequest,
+) (*getNotificationStatsHandler, error) {
+	h := &getNotificationStatsHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getNotificationStatsHandler) adapt(req *desc.GetNotificationStatsRequest) *getNotificationStatsHandler {
+	h.filter = dao.DeliveryStatsFilter{
+		UseRollup: req.GetUseRollup(),
+	}
+	if req.From != nil {
+		h.filter.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		h.filter.To = req.GetTo().AsTime()
+	}
+	h.interval = req.GetInterval()
+	h.rows = make(map[dao.StatsGroupBy][]dao.DeliveryStatsTable)
+	return h
+}
+
+func (h *getNotificationStatsHandler) validate() error {
+	if h.filter.From.IsZero() || h.filter.To.IsZero() {
+		return errors.NewNetworkError(codes.InvalidArgument, "from and to must be specified").
+			ToGRPCError()
+	}
+	if !h.filter.From.Before(h.filter.To) {
+		return errors.NewNetworkError(codes.InvalidArgument, "from must be before to").
+			ToGRPCError()
+	}
+	if _, ok := desc.StatsInterval_name[int32(h.interval)]; !ok {
+		return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("unknown interval %d", h.interval)).
+			ToGRPCError()
+	}
+	return nil
+}
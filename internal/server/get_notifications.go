@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/protobuf/types/known/timestamppb"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
 
 	desc "telegram-notification-api/api"
 )
@@ -15,7 +15,7 @@ func (s *server) GetNotifications(
 	ctx context.Context,
 	req *desc.GetNotificationsRequest,
 ) (*desc.GetNotificationsResponse, error) {
-	h, err := newGetNotificationsHandler(ctx, s.dao, req)
+	h, err := newGetNotificationsHandler(ctx, s.dao, s.paginator, req)
 	if err != nil {
 		return nil, err
 	}
@@ -31,70 +31,100 @@ func (h *getNotificationsHandler) handle() error {
 		return fmt.Errorf("got nil receiver")
 	}
 
-	notifications, err := h.dao.
-		NewNotificationQuery().
-		GetNotifications(h.ctx, h.notificationIDs, h.limit, h.offset)
+	notificationQuery := h.dao.NewNotificationQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		notifications, err := notificationQuery.GetNotificationsAfter(h.ctx, h.notificationIDs, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.notifications, h.nextPageToken, err = nextPage(h.paginator, h.req, notifications, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		notifications, err := notificationQuery.GetNotifications(
+			h.ctx,
+			h.notificationIDs,
+			newOrderBy(h.orderBy, notificationOrderFields),
+			h.limit,
+			h.offset,
+		)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.notifications = notifications
+	}
+
+	totalCount, err := notificationQuery.CountNotifications(h.ctx, h.notificationIDs)
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
-	h.notifications = notifications
+	h.totalCount = totalCount
 	return nil
 }
 
 func (h *getNotificationsHandler) response() *desc.GetNotificationsResponse {
 	notifications := make([]*desc.Notification, 0, len(h.notifications))
 	for idx := range h.notifications {
-		var mediaContent *string
-		if h.notifications[idx].MediaContent.Valid {
-			mediaContent = &h.notifications[idx].MediaContent.String
-		}
-
-		notifications = append(notifications, &desc.Notification{
-			NotificationId:     h.notifications[idx].ID,
-			SenderId:           h.notifications[idx].SenderID,
-			ReceiverIds:        h.notifications[idx].ReceiverIDs,
-			Message:            h.notifications[idx].Message,
-			MediaContent:       mediaContent,
-			NotificationStatus: desc.NotificationStatus(desc.NotificationStatus_value[h.notifications[idx].Status]),
-			Date:               timestamppb.New(h.notifications[idx].Date),
-		})
+		notifications = append(notifications, newDescNotification(h.notifications[idx]))
 	}
 
 	return &desc.GetNotificationsResponse{
-		Notification: notifications,
-		Limit:        int64(h.limit),
-		Offset:       int64(h.offset),
-		Count:        int64(len(h.notifications)),
+		Notification:  notifications,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(h.notifications)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
 	}
 }
 
 type getNotificationsHandler struct {
-	ctx context.Context
-	dao dao.DAO
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetNotificationsRequest
 
 	notificationIDs []int64
 	limit           uint64
-	offset          uint64
+	orderBy         []*desc.OrderBy
+	tokenPaging
 
 	notifications []dao.NotificationTable
+	totalCount    int64
+	nextPageToken string
 }
 
 func newGetNotificationsHandler(
 	ctx context.Context,
 	dao dao.DAO,
+	paginator *pagination.Paginator,
 	req *desc.GetNotificationsRequest,
 ) (*getNotificationsHandler, error) {
 	h := &getNotificationsHandler{
-		ctx: ctx,
-		dao: dao,
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
 	}
 	return h.adapt(req), h.validate()
 }
 
 func (h *getNotificationsHandler) adapt(req *desc.GetNotificationsRequest) *getNotificationsHandler {
-	h.offset = uint64(req.GetOffset())
-	h.limit = uint64(req.GetLimit())
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
 	h.notificationIDs = req.GetNotificationIds()
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		notificationOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
 	return h
 }
 
@@ -107,5 +137,8 @@ func (h *getNotificationsHandler) validate() error {
 		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
 			ToGRPCError()
 	}
-	return nil
+	if err := validateOrderBy(h.orderBy, notificationOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
 }
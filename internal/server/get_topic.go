@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetTopic(
+	ctx context.Context,
+	req *desc.GetTopicRequest,
+) (*desc.GetTopicResponse, error) {
+	h, err := newGetTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	topic, err := h.dao.NewTopicQuery().GetTopic(h.ctx, h.topicID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.topic = topic
+	return nil
+}
+
+func (h *getTopicHandler) response() *desc.GetTopicResponse {
+	var description *string
+	if h.topic.Description.Valid {
+		description = &h.topic.Description.String
+	}
+	return &desc.GetTopicResponse{
+		Topic: &desc.Topic{
+			TopicId:     h.topic.ID,
+			Name:        h.topic.Name,
+			Description: description,
+		},
+	}
+}
+
+type getTopicHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	topicID int64
+
+	topic dao.TopicTable
+}
+
+func newGetTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.GetTopicRequest,
+) (*getTopicHandler, error) {
+	h := &getTopicHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getTopicHandler) adapt(req *desc.GetTopicRequest) *getTopicHandler {
+	h.topicID = req.GetTopicId()
+	return h
+}
+
+func (h *getTopicHandler) validate() error {
+	if h.topicID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetTopics(
+	ctx context.Context,
+	req *desc.GetTopicsRequest,
+) (*desc.GetTopicsResponse, error) {
+	h, err := newGetTopicsHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getTopicsHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	topicQuery := h.dao.NewTopicQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		topics, err := topicQuery.GetTopicsAfter(h.ctx, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.topics, h.nextPageToken, err = nextPage(h.paginator, h.req, topics, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		topics, err := topicQuery.GetTopics(h.ctx, newOrderBy(h.orderBy, topicOrderFields), h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.topics = topics
+	}
+
+	totalCount, err := topicQuery.CountTopics(h.ctx)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *getTopicsHandler) response() *desc.GetTopicsResponse {
+	topics := make([]*desc.Topic, 0, len(h.topics))
+	for idx := range h.topics {
+		var description *string
+		if h.topics[idx].Description.Valid {
+			description = &h.topics[idx].Description.String
+		}
+		topics = append(topics, &desc.Topic{
+			TopicId:     h.topics[idx].ID,
+			Name:        h.topics[idx].Name,
+			Description: description,
+		})
+	}
+
+	return &desc.GetTopicsResponse{
+		Topics:        topics,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(topics)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type getTopicsHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetTopicsRequest
+
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
+
+	topics        []dao.TopicTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetTopicsHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetTopicsRequest,
+) (*getTopicsHandler, error) {
+	h := &getTopicsHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getTopicsHandler) adapt(req *desc.GetTopicsRequest) *getTopicsHandler {
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		topicOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
+	return h
+}
+
+func (h *getTopicsHandler) validate() error {
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	if err := validateOrderBy(h.orderBy, topicOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
+}
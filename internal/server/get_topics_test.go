@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+// topicDAO serves topics from memory, pages after a keyset are selected like applyKeyset does
+type topicDAO struct {
+	dao.DAO
+	topics []dao.TopicTable
+}
+
+func (d *topicDAO) NewTopicQuery() dao.TopicQuery {
+	return topicQuery{d: d}
+}
+
+type topicQuery struct {
+	dao.TopicQuery
+	d *topicDAO
+}
+
+func (q topicQuery) GetTopicsAfter(_ context.Context, keyset dao.Keyset, limit uint64) ([]dao.TopicTable, error) {
+	// value returns the sort key of the topic, ties are ordered by id ascending
+	value := func(topic dao.TopicTable) string {
+		if keyset.OrderBy.Column == "name" {
+			return topic.Name
+		}
+		return ""
+	}
+	less := func(a, b dao.TopicTable) bool {
+		if value(a) != value(b) {
+			return (value(a) < value(b)) != keyset.OrderBy.Desc
+		}
+		if keyset.OrderBy.Column == "name" {
+			return a.ID < b.ID
+		}
+		return (a.ID < b.ID) != keyset.OrderBy.Desc
+	}
+
+	topics := append([]dao.TopicTable(nil), q.d.topics...)
+	sort.Slice(topics, func(i, j int) bool { return less(topics[i], topics[j]) })
+	dest := make([]dao.TopicTable, 0, limit)
+	for _, topic := range topics {
+		if keyset.AfterID > 0 && !less(dao.TopicTable{ID: keyset.AfterID, Name: keyset.Value}, topic) {
+			continue
+		}
+		if uint64(len(dest)) == limit {
+			break
+		}
+		dest = append(dest, topic)
+	}
+	return dest, nil
+}
+
+func (q topicQuery) CountTopics(context.Context) (int64, error) {
+	return int64(len(q.d.topics)), nil
+}
+
+func TestGetTopicsPagedByToken(t *testing.T) {
+	paginator, err := pagination.NewPaginator(config.Pagination{MaxPageSize: 100, TokenSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &topicDAO{topics: []dao.TopicTable{
+		{ID: 1, Name: "news"},
+		{ID: 2, Name: "alerts"},
+		{ID: 3, Name: "news"},
+		{ID: 4, Name: "digest"},
+		{ID: 5, Name: "billing"},
+	}}
+
+	tests := []struct {
+		name    string
+		orderBy []*desc.OrderBy
+		wantIDs []int64
+	}{
+		{name: "by id", wantIDs: []int64{1, 2, 3, 4, 5}},
+		{
+			name:    "by name",
+			orderBy: []*desc.OrderBy{{Field: "name"}},
+			wantIDs: []int64{2, 5, 4, 1, 3},
+		},
+		{
+			name:    "by name descending",
+			orderBy: []*desc.OrderBy{{Field: "name", Direction: desc.SortDirection_DESC}},
+			wantIDs: []int64{1, 3, 4, 5, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int64
+			req := &desc.GetTopicsRequest{Limit: 2, OrderBy: tt.orderBy}
+			for pages := 0; ; pages++ {
+				if pages > len(d.topics) {
+					t.Fatal("pages don't end")
+				}
+				h, err := newGetTopicsHandler(context.Background(), d, paginator, req)
+				if err != nil {
+					t.Fatalf("newGetTopicsHandler() error = %v", err)
+				}
+				if err = h.handle(); err != nil {
+					t.Fatalf("handle() error = %v", err)
+				}
+				resp := h.response()
+				if resp.GetTotalCount() != int64(len(d.topics)) {
+					t.Errorf("total_count = %d, want %d", resp.GetTotalCount(), len(d.topics))
+				}
+				for _, topic := range resp.GetTopics() {
+					ids = append(ids, topic.GetTopicId())
+				}
+				if resp.GetNextPageToken() == "" {
+					break
+				}
+				req = &desc.GetTopicsRequest{Limit: 2, OrderBy: tt.orderBy, PageToken: resp.GetNextPageToken()}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("topics = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	t.Run("token with offset", func(t *testing.T) {
+		h, err := newGetTopicsHandler(context.Background(), d, paginator, &desc.GetTopicsRequest{Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = h.handle(); err != nil {
+			t.Fatal(err)
+		}
+		_, err = newGetTopicsHandler(context.Background(), d, paginator, &desc.GetTopicsRequest{
+			Limit:     2,
+			Offset:    2,
+			PageToken: h.response().GetNextPageToken(),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("error = %v, want InvalidArgument", err)
+		}
+	})
+}
+
+func TestPageTokenOfNullableOrder(t *testing.T) {
+	paginator, err := pagination.NewPaginator(config.Pagination{MaxPageSize: 100, TokenSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderBy := func(field string) []*desc.OrderBy {
+		return []*desc.OrderBy{{Field: field}}
+	}
+
+	tests := []struct {
+		name string
+		new  func() error
+	}{
+		{
+			name: "api clients by user_id",
+			new: func() error {
+				_, err := newGetApiClientsHandler(context.Background(), nil, paginator, &desc.GetApiClientsRequest{
+					Limit: 2, OrderBy: orderBy("user_id"), PageToken: "token",
+				})
+				return err
+			},
+		},
+		{
+			name: "audit log by actor_id",
+			new: func() error {
+				_, err := newGetAuditLogHandler(context.Background(), nil, paginator, &desc.GetAuditLogRequest{
+					Limit: 2, OrderBy: orderBy("actor_id"), PageToken: "token",
+				})
+				return err
+			},
+		},
+		{
+			name: "audit log by api_client_id",
+			new: func() error {
+				_, err := newGetAuditLogHandler(context.Background(), nil, paginator, &desc.GetAuditLogRequest{
+					Limit: 2, OrderBy: orderBy("api_client_id"), PageToken: "token",
+				})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.new(); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
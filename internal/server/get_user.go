@@ -29,44 +29,27 @@ func (h *getUserHandler) handle() error {
 	if h == nil {
 		return fmt.Errorf("got nil receiver")
 	}
-	u, err := h.dao.NewUserQuery().GetUser(h.ctx, h.userID)
+
+	userQuery := h.dao.NewUserQuery()
+	var (
+		u   dao.UserTable
+		err error
+	)
+	if h.includeDeleted {
+		u, err = userQuery.GetUserIncludingDeleted(h.ctx, h.userID)
+	} else {
+		u, err = userQuery.GetUser(h.ctx, h.userID)
+	}
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
-
-	h.Id = u.Id
-	h.TelegramId = u.TelegramId
-	h.Role = desc.UserRole_value[u.Role]
-	h.NotificationStatus = desc.UserNotificationStatus_value[u.NotificationStatus]
-	h.Group = u.Group
-	h.Firstname = u.Firstname
-	h.Surname = u.Surname
-	if u.Patronymic.Valid {
-		patronymic := u.Patronymic.String
-		h.Patronymic = &patronymic
-	}
-	h.MobilePhone = u.MobilePhone
-	h.Status = desc.UserStatus_value[u.Status]
-
+	h.gotUser = u
 	return nil
 }
 
 func (h *getUserHandler) response() *desc.GetUserResponse {
 	return &desc.GetUserResponse{
-		User: &desc.User{
-			UserId:                 h.Id,
-			TelegramId:             h.TelegramId,
-			UserRole:               desc.UserRole(h.Role),
-			UserNotificationStatus: desc.UserNotificationStatus(h.NotificationStatus),
-			Group:                  h.Group,
-			Fio: &desc.FIO{
-				Firstname:  h.Firstname,
-				Surname:    h.Surname,
-				Patronymic: h.Patronymic,
-			},
-			MobilePhone: h.MobilePhone,
-			UserStatus:  desc.UserStatus(h.Status),
-		},
+		User: newDescUser(h.gotUser),
 	}
 }
 
@@ -74,18 +57,10 @@ type getUserHandler struct {
 	ctx context.Context
 	dao dao.DAO
 
-	userID int64
+	userID         int64
+	includeDeleted bool
 
-	Id                 int64
-	TelegramId         int64
-	Role               int32
-	NotificationStatus int32
-	Group              string
-	Firstname          string
-	Surname            string
-	Patronymic         *string
-	MobilePhone        string
-	Status             int32
+	gotUser dao.UserTable
 }
 
 func newGetUserHandler(
@@ -102,6 +77,7 @@ func newGetUserHandler(
 
 func (h *getUserHandler) adapt(req *desc.GetUserRequest) *getUserHandler {
 	h.userID = req.GetUserId()
+	h.includeDeleted = req.GetIncludeDeleted()
 	return h
 }
 
@@ -31,10 +31,15 @@ func (h *getUserByTelegramIDHandler) handle() error {
 
 	users, err := h.dao.NewUserQuery().GetUserByFilter(
 		h.ctx,
-		dao.UserTable{TelegramId: h.telegramID},
+		dao.UserFilter{
+			User:   dao.UserTable{TelegramId: h.telegramID},
+			Fields: []string{"telegram_id"},
+
+			IncludeDeleted: h.includeDeleted,
+		},
+		nil,
 		1,
 		0,
-		"telegram_id",
 	)
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
@@ -48,26 +53,8 @@ func (h *getUserByTelegramIDHandler) handle() error {
 }
 
 func (h *getUserByTelegramIDHandler) response() *desc.GetUserByTelegramIDResponse {
-	var patronymic *string
-	if h.gotUser.Patronymic.Valid {
-		patronymic = &h.gotUser.Patronymic.String
-	}
-
 	return &desc.GetUserByTelegramIDResponse{
-		User: &desc.User{
-			UserId:                 h.gotUser.Id,
-			TelegramId:             h.gotUser.TelegramId,
-			UserRole:               desc.UserRole(desc.UserRole_value[h.gotUser.Role]),
-			UserNotificationStatus: desc.UserNotificationStatus(desc.UserNotificationStatus_value[h.gotUser.NotificationStatus]),
-			Group:                  h.gotUser.Group,
-			Fio: &desc.FIO{
-				Firstname:  h.gotUser.Firstname,
-				Surname:    h.gotUser.Surname,
-				Patronymic: patronymic,
-			},
-			MobilePhone: h.gotUser.MobilePhone,
-			UserStatus:  desc.UserStatus(desc.UserNotificationStatus_value[h.gotUser.Status]),
-		},
+		User: newDescUser(h.gotUser),
 	}
 }
 
@@ -75,7 +62,8 @@ type getUserByTelegramIDHandler struct {
 	ctx context.Context
 	dao dao.DAO
 
-	telegramID int64
+	telegramID     int64
+	includeDeleted bool
 
 	gotUser dao.UserTable
 }
@@ -94,6 +82,7 @@ func newGetUserByTelegramIDHandler(
 
 func (h *getUserByTelegramIDHandler) adapt(req *desc.GetUserByTelegramIDRequest) *getUserByTelegramIDHandler {
 	h.telegramID = req.GetTelegramId()
+	h.includeDeleted = req.GetIncludeDeleted()
 	return h
 }
 
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetUserDuplicates(
+	ctx context.Context,
+	req *desc.GetUserDuplicatesRequest,
+) (*desc.GetUserDuplicatesResponse, error) {
+	h, err := newGetUserDuplicatesHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getUserDuplicatesHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	userQuery := h.dao.NewUserQuery()
+	duplicates, err := userQuery.GetDuplicates(h.ctx)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	var userIDs []int64
+	for _, duplicate := range duplicates {
+		userIDs = append(userIDs, duplicate.UserIDs...)
+	}
+	if len(userIDs) > 0 {
+		users, err := userQuery.GetUsersByIds(h.ctx, userIDs, false, nil, uint64(len(userIDs)), 0)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.users = make(map[int64]dao.UserTable, len(users))
+		for _, user := range users {
+			h.users[user.Id] = user
+		}
+	}
+
+	h.duplicates = duplicates
+	return nil
+}
+
+func (h *getUserDuplicatesHandler) response() *desc.GetUserDuplicatesResponse {
+	dest := make([]*desc.UserDuplicate, 0, len(h.duplicates))
+	for _, duplicate := range h.duplicates {
+		users := make([]*desc.User, 0, len(duplicate.UserIDs))
+		for _, userID := range duplicate.UserIDs {
+			if user, ok := h.users[userID]; ok {
+				users = append(users, newDescUser(user))
+			}
+		}
+		dest = append(dest, &desc.UserDuplicate{
+			Field: duplicate.Field,
+			Value: duplicate.Value,
+			Users: users,
+		})
+	}
+	return &desc.GetUserDuplicatesResponse{
+		Duplicates: dest,
+	}
+}
+
+type getUserDuplicatesHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	duplicates []dao.UserDuplicateTable
+	users      map[int64]dao.UserTable
+}
+
+func newGetUserDuplicatesHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	_ *desc.GetUserDuplicatesRequest,
+) (*getUserDuplicatesHandler, error) {
+	h := &getUserDuplicatesHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h, nil
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+// inboxOrder is the only order of the inbox, from the newest notification
+var inboxOrder = dao.OrderBy{Column: "id", Desc: true}
+
+func (s *server) GetUserInbox(
+	ctx context.Context,
+	req *desc.GetUserInboxRequest,
+) (*desc.GetUserInboxResponse, error) {
+	h, err := newGetUserInboxHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getUserInboxHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if err := h.resolveUser(); err != nil {
+		return err
+	}
+
+	deliveryQuery := h.dao.NewDeliveryQuery()
+	if h.offset == 0 {
+		// pages go from the newest notification, so the token keeps the id the next page starts before
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, inboxOrder)
+		if err != nil {
+			return err
+		}
+		items, err := deliveryQuery.GetInboxBefore(h.ctx, h.userID, keyset.AfterID, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.items, h.nextPageToken, err = nextPage(h.paginator, h.req, items, h.limit, inboxOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		items, err := deliveryQuery.GetInbox(h.ctx, h.userID, h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.items = items
+	}
+
+	totalCount, err := deliveryQuery.CountInbox(h.ctx, h.userID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+// resolveUser finds the user id when the user is requested by telegram id
+// and checks that the user exists otherwise
+func (h *getUserInboxHandler) resolveUser() error {
+	userQuery := h.dao.NewUserQuery()
+	if h.telegramID == 0 {
+		_, err := userQuery.GetUser(h.ctx, h.userID)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	users, err := userQuery.GetUsersByTelegramIds(h.ctx, []int64{h.telegramID})
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if len(users) == 0 {
+		return errors.NewNetworkError(codes.NotFound, "user not found").ToGRPCError()
+	}
+	h.userID = users[0].Id
+	return nil
+}
+
+func (h *getUserInboxHandler) response() *desc.GetUserInboxResponse {
+	items := make([]*desc.InboxItem, 0, len(h.items))
+	for idx := range h.items {
+		items = append(items, newDescInboxItem(h.items[idx]))
+	}
+
+	return &desc.GetUserInboxResponse{
+		Items:         items,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(items)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type getUserInboxHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetUserInboxRequest
+
+	userID     int64
+	telegramID int64
+	limit      uint64
+	offset     uint64
+	pageToken  string
+
+	items         []dao.InboxTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetUserInboxHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetUserInboxRequest,
+) (*getUserInboxHandler, error) {
+	h := &getUserInboxHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getUserInboxHandler) adapt(req *desc.GetUserInboxRequest) *getUserInboxHandler {
+	h.userID = req.GetUserId()
+	h.telegramID = req.GetTelegramId()
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.offset = uint64(req.GetOffset())
+	h.pageToken = req.GetPageToken()
+	return h
+}
+
+func (h *getUserInboxHandler) validate() error {
+	if h.userID <= 0 && h.telegramID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id or telegram_id must be specified").
+			ToGRPCError()
+	}
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	return validatePageToken(h.pageToken, h.offset, true)
+}
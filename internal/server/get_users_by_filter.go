@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc/codes"
+	"strings"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
 	"telegram-notification-api/internal/types/nulltypes"
 
 	desc "telegram-notification-api/api"
@@ -15,7 +17,7 @@ func (s *server) GetUsersByFilter(
 	ctx context.Context,
 	req *desc.GetUsersByFilterRequest,
 ) (*desc.GetUsersByFilterResponse, error) {
-	h, err := newGetUsersByFilterHandler(ctx, s.dao, req)
+	h, err := newGetUsersByFilterHandler(ctx, s.dao, s.paginator, req)
 	if err != nil {
 		return nil, err
 	}
@@ -32,68 +34,84 @@ func (h *getUsersByFilterHandler) handle() error {
 		return fmt.Errorf("got nil receiver")
 	}
 
-	users, err := h.dao.NewUserQuery().
-		GetUserByFilter(h.ctx, h.filterUser, h.limit, h.offset, h.fields...)
+	userQuery := h.dao.NewUserQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		users, err := userQuery.GetUsersByFilterAfter(h.ctx, h.filter, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.filteredUsers, h.nextPageToken, err = nextPage(h.paginator, h.req, users, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		users, err := userQuery.GetUserByFilter(h.ctx, h.filter, newOrderBy(h.orderBy, userOrderFields), h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.filteredUsers = users
+	}
+
+	totalCount, err := userQuery.CountUsersByFilter(h.ctx, h.filter)
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
-
-	h.filteredUsers = users
+	h.totalCount = totalCount
 	return nil
 }
 
 func (h *getUsersByFilterHandler) response() *desc.GetUsersByFilterResponse {
 	dest := make([]*desc.User, 0, len(h.filteredUsers))
-
 	for _, user := range h.filteredUsers {
-		var patronymic *string
-		if user.Patronymic.Valid {
-			patronymic = &user.Patronymic.String
-		}
-
-		dest = append(dest, &desc.User{
-			UserId:                 user.Id,
-			TelegramId:             user.TelegramId,
-			UserRole:               desc.UserRole(desc.UserRole_value[user.Role]),
-			UserNotificationStatus: desc.UserNotificationStatus(desc.UserNotificationStatus_value[user.NotificationStatus]),
-			Group:                  user.Group,
-			Fio: &desc.FIO{
-				Firstname:  user.Firstname,
-				Surname:    user.Surname,
-				Patronymic: patronymic,
-			},
-			MobilePhone: user.MobilePhone,
-			UserStatus:  desc.UserStatus(desc.UserNotificationStatus_value[user.Status]),
-		})
+		dest = append(dest, newDescUser(user))
 	}
 	return &desc.GetUsersByFilterResponse{
-		Users:  dest,
-		Limit:  int64(h.limit),
-		Offset: int64(h.offset),
-		Count:  int64(len(dest)),
+		Users:         dest,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(dest)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
 	}
 }
 
+// pagedByToken reports whether pages of the list have tokens,
+// users found by a query are ordered by relevance and paged only with offset
+func (h *getUsersByFilterHandler) pagedByToken() bool {
+	return h.tokenPaging.pagedByToken() && strings.TrimSpace(h.filter.Query) == ""
+}
+
 type getUsersByFilterHandler struct {
-	ctx context.Context
-	dao dao.DAO
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetUsersByFilterRequest
 
-	filterUser dao.UserTable
-	fields     []string
-	limit      uint64
-	offset     uint64
+	filter  dao.UserFilter
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
 
 	filteredUsers []dao.UserTable
+	totalCount    int64
+	nextPageToken string
 }
 
 func newGetUsersByFilterHandler(
 	ctx context.Context,
 	dao dao.DAO,
+	paginator *pagination.Paginator,
 	req *desc.GetUsersByFilterRequest,
 ) (*getUsersByFilterHandler, error) {
 	h := getUsersByFilterHandler{
-		ctx: ctx,
-		dao: dao,
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
 	}
 	return h.adapt(req), h.validate()
 }
@@ -104,47 +122,93 @@ func (h *getUsersByFilterHandler) validate() error {
 			NewNetworkError(codes.InvalidArgument, "limit must be specified").
 			ToGRPCError()
 	}
-	return nil
+	if err := validateOrderBy(h.orderBy, userOrderFields); err != nil {
+		return err
+	}
+	if err := h.validatePaging(); err != nil {
+		return err
+	}
+	if h.pageToken != "" && strings.TrimSpace(h.filter.Query) != "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "page_token can't be combined with query").
+			ToGRPCError()
+	}
+	return validateUserFilter(h.filter)
 }
 
 func (h *getUsersByFilterHandler) adapt(req *desc.GetUsersByFilterRequest) *getUsersByFilterHandler {
+	h.filter = newUserFilter(req)
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		userOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
+	return h
+}
+
+// newUserFilter converts conditions of the request, limit and offset are not a part of the filter
+func newUserFilter(req *desc.GetUsersByFilterRequest) dao.UserFilter {
+	var filter dao.UserFilter
 	if req.TelegramId != nil {
-		h.filterUser.TelegramId = req.GetTelegramId()
-		h.fields = append(h.fields, "telegram_id")
+		filter.User.TelegramId = req.GetTelegramId()
+		filter.Fields = append(filter.Fields, "telegram_id")
 	}
 	if req.Firstname != nil {
-		h.filterUser.Firstname = req.GetFirstname()
-		h.fields = append(h.fields, "firstname")
+		filter.User.Firstname = req.GetFirstname()
+		filter.Fields = append(filter.Fields, "firstname")
 	}
 	if req.Surname != nil {
-		h.filterUser.Surname = req.GetSurname()
-		h.fields = append(h.fields, "surname")
+		filter.User.Surname = req.GetSurname()
+		filter.Fields = append(filter.Fields, "surname")
 	}
 	if req.Patronymic != nil {
-		h.filterUser.Patronymic = nulltypes.NewNullString(req.Patronymic)
-		h.fields = append(h.fields, "patronymic")
+		filter.User.Patronymic = nulltypes.NewNullString(req.Patronymic)
+		filter.Fields = append(filter.Fields, "patronymic")
 	}
 	if req.MobilePhone != nil {
-		h.filterUser.MobilePhone = req.GetMobilePhone()
-		h.fields = append(h.fields, "mobile_phone")
+		filter.User.MobilePhone = req.GetMobilePhone()
+		filter.Fields = append(filter.Fields, "mobile_phone")
 	}
+	filter.Groups = req.GetGroups()
 	if req.Group != nil {
-		h.filterUser.Group = req.GetGroup()
-		h.fields = append(h.fields, "user_group")
+		filter.Groups = append(filter.Groups, req.GetGroup())
 	}
 	if req.UserNotificationStatus != nil {
-		h.filterUser.NotificationStatus = req.GetUserNotificationStatus().String()
-		h.fields = append(h.fields, "notification_status")
+		filter.User.NotificationStatus = req.GetUserNotificationStatus().String()
+		filter.Fields = append(filter.Fields, "notification_status")
 	}
 	if req.UserStatus != nil {
-		h.filterUser.Status = req.GetUserStatus().String()
-		h.fields = append(h.fields, "status")
+		filter.User.Status = req.GetUserStatus().String()
+		filter.Fields = append(filter.Fields, "status")
 	}
 	if req.UserRole != nil {
-		h.filterUser.Role = req.GetUserRole().String()
-		h.fields = append(h.fields, "role")
+		filter.User.Role = req.GetUserRole().String()
+		filter.Fields = append(filter.Fields, "role")
+	}
+	filter.IncludeSubgroups = req.GetIncludeSubgroups()
+	filter.IncludeDeleted = req.GetIncludeDeleted()
+	filter.TopicID = nulltypes.NewNullInt64(req.TopicId)
+	filter.TextMatch = dao.TextMatch(req.GetTextMatch().String())
+	filter.CaseInsensitive = req.GetCaseInsensitive()
+	filter.Query = req.GetQuery()
+	return filter
+}
+
+func validateUserFilter(filter dao.UserFilter) error {
+	if filter.TopicID.Valid && filter.TopicID.Int64 <= 0 {
+		return errors.
+			NewNetworkError(codes.InvalidArgument, "topic_id must be positive").
+			ToGRPCError()
 	}
-	h.limit = uint64(req.GetLimit())
-	h.offset = uint64(req.GetOffset())
-	return h
+	switch filter.TextMatch {
+	case dao.TextMatchExact, dao.TextMatchPrefix, dao.TextMatchContains:
+	default:
+		return errors.
+			NewNetworkError(codes.InvalidArgument, "unknown text_match").
+			ToGRPCError()
+	}
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
 
 	desc "telegram-notification-api/api"
 )
@@ -14,7 +15,7 @@ func (s *server) GetUsersById(
 	ctx context.Context,
 	req *desc.GetUsersByIdRequest,
 ) (*desc.GetUsersByIdResponse, error) {
-	h, err := newGetUsersByIdHandler(ctx, s.dao, req)
+	h, err := newGetUsersByIdHandler(ctx, s.dao, s.paginator, req)
 	if err != nil {
 		return nil, err
 	}
@@ -31,62 +32,87 @@ func (h *getUserByIdHandler) handle() error {
 		return fmt.Errorf("got nil receiver")
 	}
 
-	users, err := h.dao.NewUserQuery().GetUsersByIds(h.ctx, h.userIDs, uint64(h.limit), uint64(h.offset))
+	userQuery := h.dao.NewUserQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		users, err := userQuery.GetUsersByIdsAfter(h.ctx, h.userIDs, h.includeDeleted, keyset, uint64(h.limit)+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.users, h.nextPageToken, err = nextPage(h.paginator, h.req, users, uint64(h.limit), h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		users, err := userQuery.GetUsersByIds(
+			h.ctx,
+			h.userIDs,
+			h.includeDeleted,
+			newOrderBy(h.orderBy, userOrderFields),
+			uint64(h.limit),
+			h.offset,
+		)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.users = users
+	}
+
+	totalCount, err := userQuery.CountUsersByIds(h.ctx, h.userIDs, h.includeDeleted)
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
-	h.users = users
+	h.totalCount = totalCount
 	return nil
 }
 
 func (h *getUserByIdHandler) response() *desc.GetUsersByIdResponse {
 	resp := &desc.GetUsersByIdResponse{
-		Users:  make([]*desc.User, 0, len(h.users)),
-		Limit:  h.limit,
-		Offset: h.offset,
-		Count:  int64(len(h.users)),
+		Users:         make([]*desc.User, 0, len(h.users)),
+		Limit:         h.limit,
+		Offset:        int64(h.offset),
+		Count:         int64(len(h.users)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
 	}
 
 	for idx := range h.users {
-		resp.Users = append(resp.Users, &desc.User{
-			UserId:                 h.users[idx].Id,
-			TelegramId:             h.users[idx].TelegramId,
-			UserRole:               desc.UserRole(desc.UserRole_value[h.users[idx].Role]),
-			UserNotificationStatus: desc.UserNotificationStatus(desc.UserNotificationStatus_value[h.users[idx].NotificationStatus]),
-			Group:                  h.users[idx].Group,
-			Fio: &desc.FIO{
-				Firstname: h.users[idx].Firstname,
-				Surname:   h.users[idx].Surname,
-			},
-			MobilePhone: h.users[idx].MobilePhone,
-			UserStatus:  desc.UserStatus(desc.UserStatus_value[h.users[idx].Status]),
-		})
-		if h.users[idx].Patronymic.Valid {
-			resp.Users[idx].Fio.Patronymic = &h.users[idx].Patronymic.String
-		}
+		resp.Users = append(resp.Users, newDescUser(h.users[idx]))
 	}
 	return resp
 }
 
 type getUserByIdHandler struct {
-	ctx context.Context
-	dao dao.DAO
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetUsersByIdRequest
 
-	userIDs []int64
-	limit   int64
-	offset  int64
+	userIDs        []int64
+	includeDeleted bool
+	limit          int64
+	orderBy        []*desc.OrderBy
+	tokenPaging
 
-	users []dao.UserTable
+	users         []dao.UserTable
+	totalCount    int64
+	nextPageToken string
 }
 
 func newGetUsersByIdHandler(
 	ctx context.Context,
 	dao dao.DAO,
+	paginator *pagination.Paginator,
 	req *desc.GetUsersByIdRequest,
 ) (*getUserByIdHandler, error) {
 	h := getUserByIdHandler{
-		ctx: ctx,
-		dao: dao,
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
 	}
 	return h.adapt(req), h.validate()
 }
@@ -98,15 +124,26 @@ func (h *getUserByIdHandler) validate() error {
 	if h.limit <= 0 {
 		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").ToGRPCError()
 	}
-	if h.offset < 0 {
+	if h.req.GetOffset() < 0 {
 		return errors.NewNetworkError(codes.InvalidArgument, "offset must be specified").ToGRPCError()
 	}
-	return nil
+	if err := validateOrderBy(h.orderBy, userOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
 }
 
 func (h *getUserByIdHandler) adapt(req *desc.GetUsersByIdRequest) *getUserByIdHandler {
 	h.userIDs = req.GetUserIds()
-	h.limit = req.GetLimit()
-	h.offset = req.GetOffset()
+	h.includeDeleted = req.GetIncludeDeleted()
+	h.limit = int64(h.paginator.PageSize(uint64(max(req.GetLimit(), 0))))
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(max(req.GetOffset(), 0)),
+		req.GetPageToken(),
+		h.orderBy,
+		userOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
 	return h
 }
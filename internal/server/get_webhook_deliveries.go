@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+// webhookDeliveryOrder is the order of deliveries, from the newest
+var webhookDeliveryOrder = dao.OrderBy{Column: "id", Desc: true}
+
+func (s *server) GetWebhookDeliveries(
+	ctx context.Context,
+	req *desc.GetWebhookDeliveriesRequest,
+) (*desc.GetWebhookDeliveriesResponse, error) {
+	h, err := newGetWebhookDeliveriesHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getWebhookDeliveriesHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	webhookQuery := h.dao.NewWebhookQuery()
+	if h.filter.WebhookID.Valid {
+		if _, err := webhookQuery.GetWebhook(h.ctx, h.filter.WebhookID.Int64); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		deliveries, err := webhookQuery.GetDeliveriesAfter(h.ctx, h.filter, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.deliveries, h.nextPageToken, err = nextPage(h.paginator, h.req, deliveries, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		deliveries, err := webhookQuery.GetDeliveries(h.ctx, h.filter, h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.deliveries = deliveries
+	}
+
+	totalCount, err := webhookQuery.CountDeliveries(h.ctx, h.filter)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *getWebhookDeliveriesHandler) response() *desc.GetWebhookDeliveriesResponse {
+	deliveries := make([]*desc.WebhookDelivery, 0, len(h.deliveries))
+	for idx := range h.deliveries {
+		deliveries = append(deliveries, newDescWebhookDelivery(h.deliveries[idx]))
+	}
+
+	return &desc.GetWebhookDeliveriesResponse{
+		Deliveries:    deliveries,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(deliveries)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type getWebhookDeliveriesHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetWebhookDeliveriesRequest
+
+	filter dao.WebhookDeliveryFilter
+	limit  uint64
+	tokenPaging
+
+	deliveries    []dao.WebhookDeliveryTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetWebhookDeliveriesHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetWebhookDeliveriesRequest,
+) (*getWebhookDeliveriesHandler, error) {
+	h := &getWebhookDeliveriesHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getWebhookDeliveriesHandler) adapt(req *desc.GetWebhookDeliveriesRequest) *getWebhookDeliveriesHandler {
+	h.filter.WebhookID = nulltypes.NewNullInt64(req.WebhookId)
+	for _, status := range req.GetStatuses() {
+		h.filter.Statuses = append(h.filter.Statuses, status.String())
+	}
+	h.filter.EventType = req.GetEventType()
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.tokenPaging = newTokenPaging(uint64(req.GetOffset()), req.GetPageToken(), nil, nil, webhookDeliveryOrder)
+	return h
+}
+
+func (h *getWebhookDeliveriesHandler) validate() error {
+	if h.filter.WebhookID.Valid && h.filter.WebhookID.Int64 <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "webhook_id must be positive").
+			ToGRPCError()
+	}
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	return h.validatePaging()
+}
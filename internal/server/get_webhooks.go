@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) GetWebhooks(
+	ctx context.Context,
+	req *desc.GetWebhooksRequest,
+) (*desc.GetWebhooksResponse, error) {
+	h, err := newGetWebhooksHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *getWebhooksHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	webhookQuery := h.dao.NewWebhookQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		webhooks, err := webhookQuery.GetWebhooksAfter(h.ctx, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.webhooks, h.nextPageToken, err = nextPage(h.paginator, h.req, webhooks, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		webhooks, err := webhookQuery.GetWebhooks(h.ctx, newOrderBy(h.orderBy, webhookOrderFields), h.limit, h.offset)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.webhooks = webhooks
+	}
+
+	totalCount, err := webhookQuery.CountWebhooks(h.ctx)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *getWebhooksHandler) response() *desc.GetWebhooksResponse {
+	webhooks := make([]*desc.Webhook, 0, len(h.webhooks))
+	for idx := range h.webhooks {
+		webhooks = append(webhooks, newDescWebhook(h.webhooks[idx]))
+	}
+
+	return &desc.GetWebhooksResponse{
+		Webhooks:      webhooks,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(webhooks)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type getWebhooksHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.GetWebhooksRequest
+
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
+
+	webhooks      []dao.WebhookTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newGetWebhooksHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.GetWebhooksRequest,
+) (*getWebhooksHandler, error) {
+	h := &getWebhooksHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *getWebhooksHandler) adapt(req *desc.GetWebhooksRequest) *getWebhooksHandler {
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		webhookOrderFields,
+		dao.OrderBy{Column: "id"},
+	)
+	return h
+}
+
+func (h *getWebhooksHandler) validate() error {
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	if err := validateOrderBy(h.orderBy, webhookOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
+}
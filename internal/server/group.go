@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+)
+
+func newDescGroup(group dao.GroupMembersTable) *desc.Group {
+	var description *string
+	if group.Description.Valid {
+		description = &group.Description.String
+	}
+	var parentID *int64
+	if group.ParentID.Valid {
+		parentID = &group.ParentID.Int64
+	}
+
+	return &desc.Group{
+		GroupId:       group.ID,
+		Name:          group.Name,
+		Description:   description,
+		ParentGroupId: parentID,
+		Archived:      group.Archived,
+		MemberCount:   group.MemberCount,
+	}
+}
+
+// getGroupAcceptingMembers returns a group with the name, users can't be added to absent or archived groups
+func getGroupAcceptingMembers(ctx context.Context, groupQuery dao.GroupQuery, name string) (dao.GroupTable, error) {
+	group, err := groupQuery.GetGroupByName(ctx, name)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return group, errors.NewNetworkError(codes.FailedPrecondition, fmt.Sprintf("group %q does not exist", name)).
+			ToGRPCError()
+	}
+	if err != nil {
+		return group, errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if group.Archived {
+		return group, errors.NewNetworkError(codes.FailedPrecondition, fmt.Sprintf("group %q is archived", name)).
+			ToGRPCError()
+	}
+	return group, nil
+}
+
+// addUserToGroups makes the user a member of groups with the names
+func addUserToGroups(ctx context.Context, groupQuery dao.GroupQuery, userID int64, names []string) error {
+	for _, name := range names {
+		group, err := getGroupAcceptingMembers(ctx, groupQuery, name)
+		if err != nil {
+			return err
+		}
+		if err = groupQuery.AddMember(ctx, userID, group.ID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+	return nil
+}
+
+// firstGroup fills deprecated User.group
+func firstGroup(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return groups[0]
+}
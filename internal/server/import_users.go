@@ -0,0 +1,477 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	stdErrors "errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+const (
+	importDefaultBatchSize       = 100
+	importMaxBatchSize           = 1000
+	importDefaultGroupsSeparator = ";"
+	// importDefaultMaxUploadSize is used if the upload size isn't configured
+	importDefaultMaxUploadSize = 10 << 20
+)
+
+// importFields are fields of a csv row, they are also default csv headers
+var importFields = []string{
+	"telegram_id",
+	"user_role",
+	"user_notification_status",
+	"groups",
+	"firstname",
+	"surname",
+	"patronymic",
+	"mobile_phone",
+}
+
+// importUserColumns are columns of users written from fields of a row when it updates an existing user
+var importUserColumns = map[string]string{
+	"user_role":                "role",
+	"user_notification_status": "notification_status",
+	"firstname":                "firstname",
+	"surname":                  "surname",
+	"patronymic":               "patronymic",
+	"mobile_phone":             "mobile_phone",
+}
+
+func (s *server) ImportUsers(stream desc.TelegramNotificationService_ImportUsersServer) error {
+	h := newImportUsersHandler(stream.Context(), s.dao, stream, s.importCfg.MaxUploadSize)
+	if err := h.handle(); err != nil {
+		return err
+	}
+	return stream.SendAndClose(h.response())
+}
+
+// importRow is a row of the import, it's either rejected or imported
+type importRow struct {
+	number int64
+	req    *desc.CreateUserRequest
+	// existing is the user with the same telegram_id, the row updates it
+	existing *dao.UserTable
+	err      error
+}
+
+func (h *importUsersHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	if err := h.receive(); err != nil {
+		return err
+	}
+	if h.csv.Len() > 0 {
+		if err := h.parseCSV(); err != nil {
+			return err
+		}
+	} else {
+		// rows are whole users, they have every field
+		h.fields = importFields
+	}
+	if err := h.validateRows(); err != nil {
+		return err
+	}
+	if err := h.findExisting(); err != nil {
+		return err
+	}
+	if h.options.GetDryRun() {
+		return nil
+	}
+
+	valid := make([]*importRow, 0, len(h.rows))
+	for _, row := range h.rows {
+		if row.err == nil {
+			valid = append(valid, row)
+		}
+	}
+	for start := 0; start < len(valid); start += h.batchSize {
+		batch := valid[start:min(start+h.batchSize, len(valid))]
+		if err := h.importBatch(batch); err != nil {
+			// the transaction is rolled back, so no row of the batch is imported
+			for _, row := range batch {
+				row.err = err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *importUsersHandler) receive() error {
+	for {
+		req, err := h.stream.Recv()
+		if stdErrors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch payload := req.GetPayload().(type) {
+		case *desc.ImportUsersRequest_Options:
+			if h.received > 0 {
+				return errors.NewNetworkError(codes.InvalidArgument, "options must be the first message").
+					ToGRPCError()
+			}
+			if err = h.setOptions(payload.Options); err != nil {
+				return err
+			}
+		case *desc.ImportUsersRequest_CsvChunk:
+			if len(h.rows) > 0 {
+				return errors.NewNetworkError(codes.InvalidArgument, "csv and rows can't be mixed").
+					ToGRPCError()
+			}
+			if err = h.addSize(len(payload.CsvChunk)); err != nil {
+				return err
+			}
+			h.csv.Write(payload.CsvChunk)
+		case *desc.ImportUsersRequest_Row:
+			if h.csv.Len() > 0 {
+				return errors.NewNetworkError(codes.InvalidArgument, "csv and rows can't be mixed").
+					ToGRPCError()
+			}
+			if err = h.addSize(proto.Size(payload.Row)); err != nil {
+				return err
+			}
+			h.rows = append(h.rows, &importRow{number: int64(len(h.rows) + 1), req: payload.Row})
+		default:
+			return errors.NewNetworkError(codes.InvalidArgument, "payload must be specified").
+				ToGRPCError()
+		}
+		h.received++
+	}
+}
+
+// addSize counts bytes of the upload, the whole upload is kept in memory, so its size is limited
+func (h *importUsersHandler) addSize(size int) error {
+	h.size += int64(size)
+	if h.size > h.maxSize {
+		return errors.NewNetworkError(
+			codes.ResourceExhausted,
+			fmt.Sprintf("upload exceeds the maximum size of %d bytes", h.maxSize),
+		).ToGRPCError()
+	}
+	return nil
+}
+
+func (h *importUsersHandler) setOptions(options *desc.ImportUsersOptions) error {
+	h.options = options
+
+	for field, header := range options.GetColumnMapping() {
+		if !slices.Contains(importFields, field) {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("unknown field %q in column_mapping", field)).
+				ToGRPCError()
+		}
+		if header == "" {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("column of field %q must not be empty", field)).
+				ToGRPCError()
+		}
+	}
+	if options.GetGroupsSeparator() != "" {
+		h.groupsSeparator = options.GetGroupsSeparator()
+	}
+	switch batchSize := options.GetBatchSize(); {
+	case batchSize < 0 || batchSize > importMaxBatchSize:
+		return errors.NewNetworkError(
+			codes.InvalidArgument,
+			fmt.Sprintf("batch_size must be between 1 and %d", importMaxBatchSize),
+		).ToGRPCError()
+	case batchSize > 0:
+		h.batchSize = int(batchSize)
+	}
+	return nil
+}
+
+func (h *importUsersHandler) parseCSV() error {
+	reader := csv.NewReader(&h.csv)
+	reader.TrimLeadingSpace = true
+	// short rows are allowed, missing cells are empty
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return errors.NewNetworkError(codes.InvalidArgument, "can't read csv header: "+err.Error()).
+			ToGRPCError()
+	}
+
+	// columns maps a field to its index in a record, absent fields are empty
+	columns := make(map[string]int)
+	for _, field := range importFields {
+		name := field
+		mapped, ok := h.options.GetColumnMapping()[field]
+		if ok {
+			name = mapped
+		}
+		idx := slices.Index(header, name)
+		if idx < 0 && ok {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("csv has no column %q", name)).
+				ToGRPCError()
+		}
+		if idx >= 0 {
+			columns[field] = idx
+			h.fields = append(h.fields, field)
+		}
+	}
+
+	for number := int64(1); ; number++ {
+		record, err := reader.Read()
+		if stdErrors.Is(err, io.EOF) {
+			return nil
+		}
+		row := &importRow{number: number}
+		h.rows = append(h.rows, row)
+		if err != nil {
+			row.err = err
+			continue
+		}
+		row.req, row.err = h.parseRecord(record, columns)
+	}
+}
+
+func (h *importUsersHandler) parseRecord(record []string, columns map[string]int) (*desc.CreateUserRequest, error) {
+	value := func(field string) string {
+		idx, ok := columns[field]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	req := &desc.CreateUserRequest{
+		Fio: &desc.FIO{
+			Firstname: value("firstname"),
+			Surname:   value("surname"),
+		},
+		MobilePhone: value("mobile_phone"),
+	}
+	if v := value("telegram_id"); v != "" {
+		telegramID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("telegram_id must be a number")
+		}
+		req.TelegramId = telegramID
+	}
+	if v := value("user_role"); v != "" {
+		role, ok := desc.UserRole_value[strings.ToUpper(v)]
+		if !ok {
+			return nil, fmt.Errorf("unknown user_role %q", v)
+		}
+		req.UserRole = desc.UserRole(role)
+	}
+	if v := value("user_notification_status"); v != "" {
+		notificationStatus, ok := desc.UserNotificationStatus_value[strings.ToUpper(v)]
+		if !ok {
+			return nil, fmt.Errorf("unknown user_notification_status %q", v)
+		}
+		req.UserNotificationStatus = desc.UserNotificationStatus(notificationStatus)
+	}
+	if v := value("patronymic"); v != "" {
+		req.Fio.Patronymic = &v
+	}
+	if v := value("groups"); v != "" {
+		for _, group := range strings.Split(v, h.groupsSeparator) {
+			req.Groups = append(req.Groups, strings.TrimSpace(group))
+		}
+	}
+	return req, nil
+}
+
+// validateRows checks rows with the rules of CreateUser and checks that their groups accept members
+func (h *importUsersHandler) validateRows() error {
+	groupErrs := make(map[string]error)
+	seen := make(map[int64]int64)
+	for _, row := range h.rows {
+		if row.err != nil {
+			continue
+		}
+		if _, err := newCreateUserHandler(h.ctx, h.dao, row.req); err != nil {
+			row.err = err
+			continue
+		}
+		if number, ok := seen[row.req.GetTelegramId()]; ok {
+			row.err = fmt.Errorf("telegram_id is duplicated in row %d", number)
+			continue
+		}
+		seen[row.req.GetTelegramId()] = row.number
+
+		for _, group := range row.req.GetGroups() {
+			err, ok := groupErrs[group]
+			if !ok {
+				_, err = getGroupAcceptingMembers(h.ctx, h.dao.NewGroupQuery(), group)
+				if err != nil && status.Code(err) != codes.FailedPrecondition {
+					return err
+				}
+				groupErrs[group] = err
+			}
+			if err != nil {
+				row.err = err
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (h *importUsersHandler) findExisting() error {
+	rows := make(map[int64]*importRow)
+	telegramIDs := make([]int64, 0, len(h.rows))
+	for _, row := range h.rows {
+		if row.err == nil {
+			rows[row.req.GetTelegramId()] = row
+			telegramIDs = append(telegramIDs, row.req.GetTelegramId())
+		}
+	}
+	if len(telegramIDs) == 0 {
+		return nil
+	}
+
+	users, err := h.dao.NewUserQuery().GetUsersByTelegramIds(h.ctx, telegramIDs)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	for idx := range users {
+		rows[users[idx].TelegramId].existing = &users[idx]
+	}
+	return nil
+}
+
+func (h *importUsersHandler) importBatch(batch []*importRow) error {
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		for _, row := range batch {
+			if row.existing != nil {
+				if err := h.updateUser(tx, row); err != nil {
+					return err
+				}
+				continue
+			}
+
+			createHandler, err := newCreateUserHandler(h.ctx, tx, row.req)
+			if err != nil {
+				return err
+			}
+			if err = createHandler.handle(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// updateUser overwrites fields of the existing user that are present in the upload,
+// groups of the user are replaced with groups of the row
+func (h *importUsersHandler) updateUser(tx dao.DAO, row *importRow) error {
+	userQuery := tx.NewUserQuery()
+	groupQuery := tx.NewGroupQuery()
+	userID := row.existing.Id
+
+	var columns []string
+	for _, field := range h.fields {
+		if column, ok := importUserColumns[field]; ok {
+			columns = append(columns, column)
+		}
+	}
+
+	user := dao.UserTable{
+		Id:                 userID,
+		Role:               row.req.GetUserRole().String(),
+		NotificationStatus: row.req.GetUserNotificationStatus().String(),
+		Firstname:          row.req.GetFio().GetFirstname(),
+		Surname:            row.req.GetFio().GetSurname(),
+		Patronymic:         nulltypes.NewNullString(row.req.GetFio().Patronymic),
+		MobilePhone:        row.req.GetMobilePhone(),
+	}
+	if len(columns) > 0 {
+		if _, err := userQuery.ChangeUser(h.ctx, user, columns...); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+	if err := groupQuery.RemoveAllMemberships(h.ctx, userID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if err := addUserToGroups(h.ctx, groupQuery, userID, row.req.GetGroups()); err != nil {
+		return err
+	}
+
+	after, err := userQuery.GetUser(h.ctx, userID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{userID}, *row.existing, after)
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+func (h *importUsersHandler) response() *desc.ImportUsersResponse {
+	resp := &desc.ImportUsersResponse{
+		DryRun: h.options.GetDryRun(),
+	}
+	for _, row := range h.rows {
+		switch {
+		case row.err != nil:
+			resp.Rejected++
+			resp.Errors = append(resp.Errors, &desc.ImportUsersRowError{
+				Row:        row.number,
+				TelegramId: row.req.GetTelegramId(),
+				Error:      status.Convert(row.err).Message(),
+			})
+		case row.existing != nil:
+			resp.Updated++
+		default:
+			resp.Created++
+		}
+	}
+	return resp
+}
+
+type importUsersHandler struct {
+	ctx    context.Context
+	dao    dao.DAO
+	stream desc.TelegramNotificationService_ImportUsersServer
+
+	options         *desc.ImportUsersOptions
+	groupsSeparator string
+	batchSize       int
+	received        int
+	// size is the number of bytes received, it can't exceed maxSize
+	size    int64
+	maxSize int64
+	csv     bytes.Buffer
+
+	// fields are import fields present in the upload, an update of an existing user writes only them
+	fields []string
+	rows   []*importRow
+}
+
+func newImportUsersHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	stream desc.TelegramNotificationService_ImportUsersServer,
+	maxSize int64,
+) *importUsersHandler {
+	if maxSize <= 0 {
+		maxSize = importDefaultMaxUploadSize
+	}
+	return &importUsersHandler{
+		ctx:             ctx,
+		dao:             dao,
+		stream:          stream,
+		options:         &desc.ImportUsersOptions{},
+		groupsSeparator: importDefaultGroupsSeparator,
+		batchSize:       importDefaultBatchSize,
+		maxSize:         maxSize,
+	}
+}
@@ -0,0 +1,447 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"telegram-notification-api/internal/dao"
+	"testing"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+// importDAO keeps users and groups in memory, changes of a transaction are applied at once
+type importDAO struct {
+	dao.DAO
+	users  map[int64]dao.UserTable
+	groups map[string]dao.GroupTable
+}
+
+func newImportDAO(users ...dao.UserTable) *importDAO {
+	d := &importDAO{
+		users: make(map[int64]dao.UserTable),
+		groups: map[string]dao.GroupTable{
+			"dev":      {ID: 1, Name: "dev"},
+			"ops":      {ID: 2, Name: "ops"},
+			"archived": {ID: 3, Name: "archived", Archived: true},
+		},
+	}
+	for _, user := range users {
+		d.users[user.Id] = user
+	}
+	return d
+}
+
+func (d *importDAO) WithTx(_ context.Context, f func(tx dao.DAO) error) error {
+	return f(d)
+}
+
+func (d *importDAO) NewUserQuery() dao.UserQuery {
+	return importUserQuery{d: d}
+}
+
+func (d *importDAO) NewGroupQuery() dao.GroupQuery {
+	return importGroupQuery{d: d}
+}
+
+func (d *importDAO) NewAuditLogQuery() dao.AuditLogQuery {
+	return importAuditLogQuery{}
+}
+
+func (d *importDAO) NewWebhookQuery() dao.WebhookQuery {
+	return importWebhookQuery{}
+}
+
+type importUserQuery struct {
+	dao.UserQuery
+	d *importDAO
+}
+
+func (q importUserQuery) GetUser(_ context.Context, userID int64) (dao.UserTable, error) {
+	user, ok := q.d.users[userID]
+	if !ok {
+		return user, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (q importUserQuery) GetUsersByTelegramIds(_ context.Context, telegramIDs []int64) ([]dao.UserTable, error) {
+	var users []dao.UserTable
+	for _, user := range q.d.users {
+		if slices.Contains(telegramIDs, user.TelegramId) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (q importUserQuery) CreateUser(
+	_ context.Context,
+	telegramID int64,
+	userRole string,
+	userNotificationStatus string,
+	firstname string,
+	lastname string,
+	patronymic sql.NullString,
+	mobilePhone string,
+	userStatus string,
+) (dao.UserTable, error) {
+	user := dao.UserTable{
+		Id:                 int64(len(q.d.users) + 1),
+		TelegramId:         telegramID,
+		Role:               userRole,
+		NotificationStatus: userNotificationStatus,
+		Firstname:          firstname,
+		Surname:            lastname,
+		Patronymic:         patronymic,
+		MobilePhone:        mobilePhone,
+		Status:             userStatus,
+	}
+	q.d.users[user.Id] = user
+	return user, nil
+}
+
+func (q importUserQuery) ChangeUser(_ context.Context, user dao.UserTable, fields ...string) (dao.UserTable, error) {
+	stored := q.d.users[user.Id]
+	for _, field := range fields {
+		switch field {
+		case "role":
+			stored.Role = user.Role
+		case "notification_status":
+			stored.NotificationStatus = user.NotificationStatus
+		case "firstname":
+			stored.Firstname = user.Firstname
+		case "surname":
+			stored.Surname = user.Surname
+		case "patronymic":
+			stored.Patronymic = user.Patronymic
+		case "mobile_phone":
+			stored.MobilePhone = user.MobilePhone
+		}
+	}
+	q.d.users[user.Id] = stored
+	return stored, nil
+}
+
+type importGroupQuery struct {
+	dao.GroupQuery
+	d *importDAO
+}
+
+func (q importGroupQuery) GetGroupByName(_ context.Context, name string) (dao.GroupTable, error) {
+	group, ok := q.d.groups[name]
+	if !ok {
+		return group, sql.ErrNoRows
+	}
+	return group, nil
+}
+
+func (q importGroupQuery) AddMember(_ context.Context, userID int64, groupID int64) error {
+	user := q.d.users[userID]
+	for name, group := range q.d.groups {
+		if group.ID == groupID {
+			user.Groups = append(user.Groups, name)
+		}
+	}
+	q.d.users[userID] = user
+	return nil
+}
+
+func (q importGroupQuery) RemoveAllMemberships(_ context.Context, userID int64) error {
+	user := q.d.users[userID]
+	user.Groups = nil
+	q.d.users[userID] = user
+	return nil
+}
+
+type importAuditLogQuery struct {
+	dao.AuditLogQuery
+}
+
+func (importAuditLogQuery) CreateAuditRecord(context.Context, dao.AuditLogTable) error {
+	return nil
+}
+
+type importWebhookQuery struct {
+	dao.WebhookQuery
+}
+
+func (importWebhookQuery) EnqueueDeliveries(context.Context, string, types.JSONText, time.Time) error {
+	return nil
+}
+
+// importStream sends the messages of a client to the handler
+type importStream struct {
+	grpc.ServerStream
+	messages []*desc.ImportUsersRequest
+}
+
+func (s *importStream) Recv() (*desc.ImportUsersRequest, error) {
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	req := s.messages[0]
+	s.messages = s.messages[1:]
+	return req, nil
+}
+
+func (s *importStream) SendAndClose(*desc.ImportUsersResponse) error {
+	return nil
+}
+
+func optionsMessage(options *desc.ImportUsersOptions) *desc.ImportUsersRequest {
+	return &desc.ImportUsersRequest{Payload: &desc.ImportUsersRequest_Options{Options: options}}
+}
+
+// csvMessages splits the document into chunks, a chunk boundary can be anywhere in a line
+func csvMessages(document string, chunkSize int) []*desc.ImportUsersRequest {
+	var messages []*desc.ImportUsersRequest
+	for start := 0; start < len(document); start += chunkSize {
+		chunk := document[start:min(start+chunkSize, len(document))]
+		messages = append(messages, &desc.ImportUsersRequest{
+			Payload: &desc.ImportUsersRequest_CsvChunk{CsvChunk: []byte(chunk)},
+		})
+	}
+	return messages
+}
+
+func rowMessage(row *desc.CreateUserRequest) *desc.ImportUsersRequest {
+	return &desc.ImportUsersRequest{Payload: &desc.ImportUsersRequest_Row{Row: row}}
+}
+
+// existingAdmin is a user every case starts with, imports with telegram_id 100 update it
+var existingAdmin = dao.UserTable{
+	Id:                 1,
+	TelegramId:         100,
+	Role:               desc.UserRole_ADMIN.String(),
+	NotificationStatus: desc.UserNotificationStatus_DISABLE.String(),
+	Firstname:          "Ivan",
+	Surname:            "Petrov",
+	Patronymic:         sql.NullString{String: "Ivanovich", Valid: true},
+	MobilePhone:        "+70000000001",
+	Status:             desc.UserStatus_ACTIVE.String(),
+	Groups:             []string{"ops"},
+}
+
+func TestImportUsers(t *testing.T) {
+	withAdmin := func(change func(user *dao.UserTable)) dao.UserTable {
+		user := existingAdmin
+		user.Groups = slices.Clone(existingAdmin.Groups)
+		change(&user)
+		return user
+	}
+	newUser := dao.UserTable{
+		Id:                 2,
+		TelegramId:         200,
+		Role:               desc.UserRole_READER.String(),
+		NotificationStatus: desc.UserNotificationStatus_ON.String(),
+		Firstname:          "Anna",
+		Surname:            "Sidorova",
+		MobilePhone:        "+70000000002",
+		Status:             desc.UserStatus_ACTIVE.String(),
+		Groups:             []string{"dev"},
+	}
+
+	tests := []struct {
+		name     string
+		maxSize  int64
+		messages []*desc.ImportUsersRequest
+		want     *desc.ImportUsersResponse
+		// wantUsers are all users stored after a successful import
+		wantUsers []dao.UserTable
+		wantCode  codes.Code
+	}{
+		{
+			name: "csv without role columns keeps role, notification status and patronymic",
+			messages: csvMessages(
+				"telegram_id,groups,firstname,surname,mobile_phone\n"+
+					"100,dev,Ivan,Sidorov,+70000000001\n",
+				7,
+			),
+			want: &desc.ImportUsersResponse{Updated: 1},
+			wantUsers: []dao.UserTable{withAdmin(func(user *dao.UserTable) {
+				user.Surname = "Sidorov"
+				user.Groups = []string{"dev"}
+			})},
+		},
+		{
+			name: "csv with mapped columns",
+			messages: append(
+				[]*desc.ImportUsersRequest{optionsMessage(&desc.ImportUsersOptions{
+					ColumnMapping:   map[string]string{"groups": "Groups", "user_role": "Role"},
+					GroupsSeparator: "|",
+				})},
+				csvMessages("telegram_id,Groups,Role,firstname,surname,mobile_phone\n"+
+					"100,ops|dev,writer,Ivan,Petrov,+70000000001\n", 64)...,
+			),
+			want: &desc.ImportUsersResponse{Updated: 1},
+			wantUsers: []dao.UserTable{withAdmin(func(user *dao.UserTable) {
+				user.Role = desc.UserRole_WRITER.String()
+				user.Groups = []string{"dev", "ops"}
+			})},
+		},
+		{
+			name: "csv with every column",
+			messages: csvMessages(
+				"telegram_id,user_role,user_notification_status,groups,firstname,surname,patronymic,mobile_phone\n"+
+					"100, reader, on, dev;ops, Ivan, Petrov, , +70000000001\n"+
+					"200,,,dev,Anna,Sidorova,,+70000000002\n",
+				16,
+			),
+			want: &desc.ImportUsersResponse{Created: 1, Updated: 1},
+			wantUsers: []dao.UserTable{
+				withAdmin(func(user *dao.UserTable) {
+					user.Role = desc.UserRole_READER.String()
+					user.NotificationStatus = desc.UserNotificationStatus_ON.String()
+					user.Patronymic = sql.NullString{}
+					user.Groups = []string{"dev", "ops"}
+				}),
+				newUser,
+			},
+		},
+		{
+			name: "rows update every field",
+			messages: []*desc.ImportUsersRequest{rowMessage(&desc.CreateUserRequest{
+				TelegramId:  100,
+				Groups:      []string{"dev"},
+				Fio:         &desc.FIO{Firstname: "Ivan", Surname: "Petrov"},
+				MobilePhone: "+70000000001",
+			})},
+			want: &desc.ImportUsersResponse{Updated: 1},
+			wantUsers: []dao.UserTable{withAdmin(func(user *dao.UserTable) {
+				user.Role = desc.UserRole_READER.String()
+				user.NotificationStatus = desc.UserNotificationStatus_ON.String()
+				user.Patronymic = sql.NullString{}
+				user.Groups = []string{"dev"}
+			})},
+		},
+		{
+			name: "invalid rows are rejected one by one",
+			messages: csvMessages(
+				"telegram_id,user_role,groups,firstname,surname,mobile_phone\n"+
+					"abc,,dev,Anna,Sidorova,+70000000002\n"+
+					"200,owner,dev,Anna,Sidorova,+70000000002\n"+
+					"200,,,Anna,Sidorova,+70000000002\n"+
+					"201,,archived,Anna,Sidorova,+70000000002\n"+
+					"202,,unknown,Anna,Sidorova,+70000000002\n"+
+					"200,,dev,Anna,Sidorova,+70000000002\n"+
+					"200,,dev,Anna,Sidorova,+70000000002\n",
+				64,
+			),
+			want: &desc.ImportUsersResponse{
+				Created:  1,
+				Rejected: 6,
+				Errors: []*desc.ImportUsersRowError{
+					{Row: 1, Error: "telegram_id must be a number"},
+					{Row: 2, Error: `unknown user_role "owner"`},
+					{Row: 3, TelegramId: 200, Error: "groups must be specified"},
+					{Row: 4, TelegramId: 201, Error: `group "archived" is archived`},
+					{Row: 5, TelegramId: 202, Error: `group "unknown" does not exist`},
+					{Row: 7, TelegramId: 200, Error: "telegram_id is duplicated in row 6"},
+				},
+			},
+			wantUsers: []dao.UserTable{existingAdmin, newUser},
+		},
+		{
+			name: "dry run",
+			messages: append(
+				[]*desc.ImportUsersRequest{optionsMessage(&desc.ImportUsersOptions{DryRun: true})},
+				csvMessages("telegram_id,user_role,groups,firstname,surname,mobile_phone\n100,reader,dev,I,P,+7\n", 64)...,
+			),
+			want:      &desc.ImportUsersResponse{DryRun: true, Updated: 1},
+			wantUsers: []dao.UserTable{existingAdmin},
+		},
+		{
+			name:     "upload over the maximum size",
+			maxSize:  32,
+			messages: csvMessages("telegram_id,groups,firstname,surname,mobile_phone\n", 16),
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name: "mapped column is absent",
+			messages: append(
+				[]*desc.ImportUsersRequest{optionsMessage(&desc.ImportUsersOptions{
+					ColumnMapping: map[string]string{"telegram_id": "Telegram"},
+				})},
+				csvMessages("telegram_id\n100\n", 64)...,
+			),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "csv and rows mixed",
+			messages: append(
+				csvMessages("telegram_id\n", 64),
+				rowMessage(&desc.CreateUserRequest{TelegramId: 200}),
+			),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "options after data",
+			messages: append(
+				csvMessages("telegram_id\n", 64),
+				optionsMessage(&desc.ImportUsersOptions{}),
+			),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "batch size too large",
+			messages: []*desc.ImportUsersRequest{
+				optionsMessage(&desc.ImportUsersOptions{BatchSize: importMaxBatchSize + 1}),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newImportDAO(withAdmin(func(*dao.UserTable) {}))
+			stream := &importStream{messages: tt.messages}
+			h := newImportUsersHandler(context.Background(), d, stream, tt.maxSize)
+
+			err := h.handle()
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("handle() code = %s, want %s (err = %v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			got := h.response()
+			if got.GetDryRun() != tt.want.GetDryRun() ||
+				got.GetCreated() != tt.want.GetCreated() ||
+				got.GetUpdated() != tt.want.GetUpdated() ||
+				got.GetRejected() != tt.want.GetRejected() {
+				t.Errorf("response() = %v, want %v", got, tt.want)
+			}
+			if len(got.GetErrors()) != len(tt.want.GetErrors()) {
+				t.Fatalf("response() errors = %v, want %v", got.GetErrors(), tt.want.GetErrors())
+			}
+			for idx, rowErr := range got.GetErrors() {
+				want := tt.want.GetErrors()[idx]
+				if rowErr.GetRow() != want.GetRow() ||
+					rowErr.GetTelegramId() != want.GetTelegramId() ||
+					!strings.Contains(rowErr.GetError(), want.GetError()) {
+					t.Errorf("response() error %d = %v, want %v", idx, rowErr, want)
+				}
+			}
+
+			for _, want := range tt.wantUsers {
+				user := d.users[want.Id]
+				slices.Sort(user.Groups)
+				if !reflect.DeepEqual(user, want) {
+					t.Errorf("user %d = %+v, want %+v", want.Id, user, want)
+				}
+			}
+			if len(d.users) != len(tt.wantUsers) {
+				t.Errorf("got %d users, want %d", len(d.users), len(tt.wantUsers))
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"slices"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) MergeGroups(
+	ctx context.Context,
+	req *desc.MergeGroupsRequest,
+) (*desc.MergeGroupsResponse, error) {
+	h, err := newMergeGroupsHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *mergeGroupsHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+
+		sources := make([]dao.GroupMembersTable, 0, len(h.sourceIDs))
+		for _, sourceID := range h.sourceIDs {
+			source, err := groupQuery.GetGroup(h.ctx, sourceID)
+			if err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+			sources = append(sources, source)
+		}
+		target, err := groupQuery.GetGroup(h.ctx, h.targetID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		ancestors, err := groupQuery.GetAncestorIDs(h.ctx, h.targetID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, sourceID := range h.sourceIDs {
+			if slices.Contains(ancestors, sourceID) {
+				return errors.NewNetworkError(codes.InvalidArgument, "target group can't be a subgroup of a source group").
+					ToGRPCError()
+			}
+		}
+
+		if err = groupQuery.MoveMembers(h.ctx, h.sourceIDs, h.targetID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = groupQuery.MoveSubgroups(h.ctx, h.sourceIDs, h.targetID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		for _, sourceID := range h.sourceIDs {
+			if err = groupQuery.DeleteGroup(h.ctx, sourceID); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+		}
+
+		group, err := groupQuery.GetGroup(h.ctx, h.targetID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.mergedGroup = group
+
+		for _, source := range sources {
+			if err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{source.ID}, source, nil); err != nil {
+				return errors.WrapToNetwork(err).ToGRPCError()
+			}
+		}
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{group.ID}, target, group)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *mergeGroupsHandler) response() *desc.MergeGroupsResponse {
+	return &desc.MergeGroupsResponse{
+		Group: newDescGroup(h.mergedGroup),
+	}
+}
+
+type mergeGroupsHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	sourceIDs []int64
+	targetID  int64
+
+	mergedGroup dao.GroupMembersTable
+}
+
+func newMergeGroupsHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.MergeGroupsRequest,
+) (*mergeGroupsHandler, error) {
+	h := &mergeGroupsHandler{
+		ctx:       ctx,
+		dao:       dao,
+		sourceIDs: req.GetSourceGroupIds(),
+		targetID:  req.GetTargetGroupId(),
+	}
+	return h, h.validate()
+}
+
+func (h *mergeGroupsHandler) validate() error {
+	if len(h.sourceIDs) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "source_group_ids must be specified").
+			ToGRPCError()
+	}
+	if h.targetID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "target_group_id must be specified").
+			ToGRPCError()
+	}
+	if slices.Contains(h.sourceIDs, h.targetID) {
+		return errors.NewNetworkError(codes.InvalidArgument, "source_group_ids can't contain target_group_id").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"telegram-notification-api/internal/dao"
+
+	desc "telegram-notification-api/api"
+)
+
+func newDescNotification(notification dao.NotificationTable) *desc.Notification {
+	var mediaContent *string
+	if notification.MediaContent.Valid {
+		mediaContent = &notification.MediaContent.String
+	}
+	var topicID *int64
+	if notification.TopicID.Valid {
+		topicID = &notification.TopicID.Int64
+	}
+	return &desc.Notification{
+		NotificationId:     notification.ID,
+		SenderId:           notification.SenderID,
+		ReceiverIds:        notification.ReceiverIDs,
+		Message:            notification.Message,
+		MediaContent:       mediaContent,
+		NotificationStatus: desc.NotificationStatus(desc.NotificationStatus_value[notification.Status]),
+		Date:               timestamppb.New(notification.Date),
+		TopicId:            topicID,
+	}
+}
+
+func newDescInboxItem(item dao.InboxTable) *desc.InboxItem {
+	var deliveryError *string
+	if item.DeliveryError.Valid {
+		deliveryError = &item.DeliveryError.String
+	}
+	return &desc.InboxItem{
+		Notification:   newDescNotification(item.NotificationTable),
+		DeliveryStatus: desc.DeliveryStatus(desc.DeliveryStatus_value[item.DeliveryStatus]),
+		DeliveryError:  deliveryError,
+		CreatedAt:      timestamppb.New(item.DeliveryCreatedAt),
+		UpdatedAt:      timestamppb.New(item.DeliveryUpdatedAt),
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+// fields lists can be ordered by and columns they are sorted by
+var (
+	userOrderFields = map[string]string{
+		"user_id":                  "id",
+		"telegram_id":              "telegram_id",
+		"user_role":                "role",
+		"user_notification_status": "notification_status",
+		"firstname":                "firstname",
+		"surname":                  "surname",
+		"patronymic":               "patronymic",
+		"mobile_phone":             "mobile_phone",
+		"user_status":              "status",
+		"deleted_at":               "deleted_at",
+	}
+	notificationOrderFields = map[string]string{
+		"notification_id":     "id",
+		"sender_id":           "sender_id",
+		"notification_status": "status",
+		"date":                "date",
+		"topic_id":            "topic_id",
+	}
+	topicOrderFields = map[string]string{
+		"topic_id": "id",
+		"name":     "name",
+	}
+	apiClientOrderFields = map[string]string{
+		"api_client_id": "id",
+		"name":          "name",
+		"user_id":       "user_id",
+		"created_at":    "created_at",
+	}
+	webhookOrderFields = map[string]string{
+		"webhook_id": "id",
+		"url":        "url",
+		"active":     "active",
+		"created_at": "created_at",
+	}
+	auditRecordOrderFields = map[string]string{
+		"audit_record_id": "id",
+		"actor_id":        "actor_id",
+		"api_client_id":   "api_client_id",
+		"method":          "method",
+		"target_type":     "target_type",
+		"created_at":      "created_at",
+	}
+)
+
+// nullableOrderColumns can be empty, lists ordered by them can't be paged with tokens
+var nullableOrderColumns = []string{"patronymic", "deleted_at", "topic_id", "user_id", "actor_id", "api_client_id"}
+
+func validateOrderBy(orderBy []*desc.OrderBy, fields map[string]string) error {
+	for _, key := range orderBy {
+		if _, ok := fields[key.GetField()]; !ok {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("can't order by %q", key.GetField())).
+				ToGRPCError()
+		}
+		if _, ok := desc.SortDirection_name[int32(key.GetDirection())]; !ok {
+			return errors.NewNetworkError(codes.InvalidArgument, "unknown order_by direction").
+				ToGRPCError()
+		}
+	}
+	return nil
+}
+
+// newOrderBy converts sort keys checked by validateOrderBy
+func newOrderBy(orderBy []*desc.OrderBy, fields map[string]string) []dao.OrderBy {
+	dest := make([]dao.OrderBy, 0, len(orderBy))
+	for _, key := range orderBy {
+		dest = append(dest, dao.OrderBy{
+			Column: fields[key.GetField()],
+			Desc:   key.GetDirection() == desc.SortDirection_DESC,
+		})
+	}
+	return dest
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	stdErrors "errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"slices"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+
+	desc "telegram-notification-api/api"
+)
+
+// keysetItem is an item of a list paged with tokens
+type keysetItem interface {
+	Keyset(orderBy dao.OrderBy) dao.Keyset
+}
+
+// tokenPaging is the paging of a list that is paged either with offset or with page tokens,
+// handlers of such lists embed it
+type tokenPaging struct {
+	offset    uint64
+	pageToken string
+	// tokenOrder is the order of pages with tokens, tokenPaged is false if order_by of the request allows only offset
+	tokenOrder dao.OrderBy
+	tokenPaged bool
+}
+
+// newTokenPaging takes the paging of a request, the list is ordered by byDefault if orderBy is empty
+func newTokenPaging(
+	offset uint64,
+	pageToken string,
+	orderBy []*desc.OrderBy,
+	fields map[string]string,
+	byDefault dao.OrderBy,
+) tokenPaging {
+	p := tokenPaging{offset: offset, pageToken: pageToken}
+	p.tokenOrder, p.tokenPaged = keysetOrder(orderBy, fields, byDefault)
+	return p
+}
+
+// pagedByToken reports whether pages of the list have tokens
+func (p tokenPaging) pagedByToken() bool {
+	return p.offset == 0 && p.tokenPaged
+}
+
+// validatePaging checks that the page token isn't combined with other ways to page the list
+func (p tokenPaging) validatePaging() error {
+	return validatePageToken(p.pageToken, p.offset, p.tokenPaged)
+}
+
+// keysetOrder returns the order of a list paged with tokens, byDefault if orderBy is empty.
+// Lists ordered by several keys or by a nullable column can be paged only with offset.
+func keysetOrder(orderBy []*desc.OrderBy, fields map[string]string, byDefault dao.OrderBy) (dao.OrderBy, bool) {
+	if len(orderBy) == 0 {
+		return byDefault, true
+	}
+	if len(orderBy) > 1 {
+		return dao.OrderBy{}, false
+	}
+	key := newOrderBy(orderBy, fields)[0]
+	if slices.Contains(nullableOrderColumns, key.Column) {
+		return dao.OrderBy{}, false
+	}
+	return key, true
+}
+
+// validatePageToken checks that a page token isn't combined with other ways to page a list,
+// keyset tells whether the order of the list allows tokens
+func validatePageToken(pageToken string, offset uint64, keyset bool) error {
+	if pageToken == "" {
+		return nil
+	}
+	if offset > 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "page_token can't be combined with offset").
+			ToGRPCError()
+	}
+	if !keyset {
+		return errors.NewNetworkError(
+			codes.InvalidArgument,
+			"page_token can be combined only with order_by of one field that is always set",
+		).ToGRPCError()
+	}
+	return nil
+}
+
+// pageKeyset returns the position the page of the token starts after, the first page has no position
+func pageKeyset(
+	paginator *pagination.Paginator,
+	req proto.Message,
+	pageToken string,
+	orderBy dao.OrderBy,
+) (dao.Keyset, error) {
+	keyset := dao.Keyset{OrderBy: orderBy}
+	if pageToken == "" {
+		return keyset, nil
+	}
+	cursor, err := paginator.Cursor(req, pageToken)
+	if stdErrors.Is(err, pagination.ErrInvalidToken) {
+		return keyset, errors.NewNetworkError(codes.InvalidArgument, err.Error()).ToGRPCError()
+	}
+	keyset.AfterID, keyset.Value = cursor.AfterID, cursor.Value
+	return keyset, errors.WrapToNetwork(err).ToGRPCError()
+}
+
+// nextPage cuts items to the page size, items are fetched with one extra item
+// to find out whether the next page exists, its token is returned then
+func nextPage[T keysetItem](
+	paginator *pagination.Paginator,
+	req proto.Message,
+	items []T,
+	limit uint64,
+	orderBy dao.OrderBy,
+) ([]T, string, error) {
+	if uint64(len(items)) <= limit {
+		return items, "", nil
+	}
+	items = items[:limit]
+	last := items[len(items)-1].Keyset(orderBy)
+	token, err := paginator.NextToken(req, pagination.Cursor{AfterID: last.AfterID, Value: last.Value})
+	if err != nil {
+		return nil, "", errors.WrapToNetwork(err).ToGRPCError()
+	}
+	return items, token, nil
+}
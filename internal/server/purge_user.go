@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) PurgeUser(
+	ctx context.Context,
+	req *desc.PurgeUserRequest,
+) (*desc.PurgeUserResponse, error) {
+	h, err := newPurgeUserHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *purgeUserHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		user, err := tx.NewUserQuery().GetUserIncludingDeleted(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if user.PurgedAt.Valid {
+			return errors.NewNetworkError(codes.FailedPrecondition, "user is already purged").
+				ToGRPCError()
+		}
+
+		if err = purgeUser(h.ctx, tx, h.userID); err != nil {
+			return err
+		}
+
+		// personal data must not stay in the audit log, so only the fact of purging is recorded
+		purged := map[string]interface{}{"purged": true}
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{h.userID}, nil, purged)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+// userPersonalDataFields are replaced by PurgeUser and hidden in the audit log
+var userPersonalDataFields = []string{
+	"telegram_id",
+	"firstname",
+	"surname",
+	"patronymic",
+	"mobile_phone",
+}
+
+// purgeUser erases personal data of the user, also in the audit log and webhook payloads,
+// and its links to groups and topics, tx must be a transaction
+func purgeUser(ctx context.Context, tx dao.DAO, userID int64) error {
+	groupQuery := tx.NewGroupQuery()
+
+	if err := tx.NewUserQuery().PurgeUser(ctx, userID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if err := groupQuery.RemoveAllMemberships(ctx, userID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if err := groupQuery.RemoveAllModerators(ctx, userID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if err := tx.NewTopicQuery().UnsubscribeFromAll(ctx, userID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	err := tx.NewAuditLogQuery().RedactChanges(ctx, audit.TargetUser, []int64{userID}, userPersonalDataFields)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	err = tx.NewWebhookQuery().RedactDeliveryPayloads(ctx, userID, userPersonalDataFields)
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+func (h *purgeUserHandler) response() *desc.PurgeUserResponse {
+	return &desc.PurgeUserResponse{}
+}
+
+type purgeUserHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID int64
+}
+
+func newPurgeUserHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.PurgeUserRequest,
+) (*purgeUserHandler, error) {
+	h := &purgeUserHandler{
+		ctx:    ctx,
+		dao:    dao,
+		userID: req.GetUserId(),
+	}
+	return h, h.validate()
+}
+
+func (h *purgeUserHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) RemoveGroupModerator(
+	ctx context.Context,
+	req *desc.RemoveGroupModeratorRequest,
+) (*desc.RemoveGroupModeratorResponse, error) {
+	h, err := newRemoveGroupModeratorHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *removeGroupModeratorHandler) response() *desc.RemoveGroupModeratorResponse {
+	return &desc.RemoveGroupModeratorResponse{}
+}
+
+func (h *removeGroupModeratorHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		if err := tx.NewGroupQuery().RemoveModerator(h.ctx, h.userID, h.groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		moderator := map[string]interface{}{"moderator_id": h.userID}
+		err := audit.Record(h.ctx, tx, audit.TargetGroup, []int64{h.groupID}, moderator, nil)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type removeGroupModeratorHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID  int64
+	groupID int64
+}
+
+func newRemoveGroupModeratorHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.RemoveGroupModeratorRequest,
+) (*removeGroupModeratorHandler, error) {
+	h := &removeGroupModeratorHandler{
+		ctx:     ctx,
+		dao:     dao,
+		userID:  req.GetUserId(),
+		groupID: req.GetGroupId(),
+	}
+	return h, h.validate()
+}
+
+func (h *removeGroupModeratorHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) RemoveUserFromGroup(
+	ctx context.Context,
+	req *desc.RemoveUserFromGroupRequest,
+) (*desc.RemoveUserFromGroupResponse, error) {
+	h, err := newRemoveUserFromGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *removeUserFromGroupHandler) response() *desc.RemoveUserFromGroupResponse {
+	return &desc.RemoveUserFromGroupResponse{}
+}
+
+func (h *removeUserFromGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+		userQuery := tx.NewUserQuery()
+		if err := authz.CheckGroupScope(h.ctx, groupQuery, []int64{h.groupID}, nil); err != nil {
+			return err
+		}
+
+		before, err := userQuery.GetUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if err = groupQuery.RemoveMember(h.ctx, h.userID, h.groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		after, err := userQuery.GetUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{h.userID}, before, after)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type removeUserFromGroupHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID  int64
+	groupID int64
+}
+
+func newRemoveUserFromGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.RemoveUserFromGroupRequest,
+) (*removeUserFromGroupHandler, error) {
+	h := &removeUserFromGroupHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *removeUserFromGroupHandler) adapt(req *desc.RemoveUserFromGroupRequest) *removeUserFromGroupHandler {
+	h.userID = req.GetUserId()
+	h.groupID = req.GetGroupId()
+	return h
+}
+
+func (h *removeUserFromGroupHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
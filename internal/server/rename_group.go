@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+// RenameGroup changes the name of the group, memberships refer to groups by id
+// so users of the group follow the new name
+func (s *server) RenameGroup(
+	ctx context.Context,
+	req *desc.RenameGroupRequest,
+) (*desc.RenameGroupResponse, error) {
+	h, err := newRenameGroupHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *renameGroupHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		groupQuery := tx.NewGroupQuery()
+
+		before, err := groupQuery.GetGroup(h.ctx, h.groupID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		_, err = groupQuery.ChangeGroup(h.ctx, dao.GroupTable{ID: h.groupID, Name: h.name}, "name")
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		group, err := groupQuery.GetGroup(h.ctx, h.groupID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.renamedGroup = group
+
+		err = audit.Record(h.ctx, tx, audit.TargetGroup, []int64{group.ID}, before.GroupTable, group.GroupTable)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *renameGroupHandler) response() *desc.RenameGroupResponse {
+	return &desc.RenameGroupResponse{
+		Group: newDescGroup(h.renamedGroup),
+	}
+}
+
+type renameGroupHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	groupID int64
+	name    string
+
+	renamedGroup dao.GroupMembersTable
+}
+
+func newRenameGroupHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.RenameGroupRequest,
+) (*renameGroupHandler, error) {
+	h := &renameGroupHandler{
+		ctx:     ctx,
+		dao:     dao,
+		groupID: req.GetGroupId(),
+		name:    req.GetName(),
+	}
+	return h, h.validate()
+}
+
+func (h *renameGroupHandler) validate() error {
+	if h.groupID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "group_id must be specified").
+			ToGRPCError()
+	}
+	if h.name == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "name must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) RestoreUser(
+	ctx context.Context,
+	req *desc.RestoreUserRequest,
+) (*desc.RestoreUserResponse, error) {
+	h, err := newRestoreUserHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *restoreUserHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		userQuery := tx.NewUserQuery()
+
+		before, err := userQuery.GetUserIncludingDeleted(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if !before.DeletedAt.Valid {
+			return errors.NewNetworkError(codes.FailedPrecondition, "user is not deleted").
+				ToGRPCError()
+		}
+		if before.PurgedAt.Valid {
+			return errors.NewNetworkError(codes.FailedPrecondition, "user is purged and can't be restored").
+				ToGRPCError()
+		}
+
+		if err = userQuery.RestoreUser(h.ctx, h.userID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		user, err := userQuery.GetUser(h.ctx, h.userID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.restoredUser = user
+
+		err = audit.Record(h.ctx, tx, audit.TargetUser, []int64{h.userID}, before, user)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *restoreUserHandler) response() *desc.RestoreUserResponse {
+	return &desc.RestoreUserResponse{
+		User: newDescUser(h.restoredUser),
+	}
+}
+
+type restoreUserHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID int64
+
+	restoredUser dao.UserTable
+}
+
+func newRestoreUserHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.RestoreUserRequest,
+) (*restoreUserHandler, error) {
+	h := &restoreUserHandler{
+		ctx:    ctx,
+		dao:    dao,
+		userID: req.GetUserId(),
+	}
+	return h, h.validate()
+}
+
+func (h *restoreUserHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) RetryWebhookDelivery(
+	ctx context.Context,
+	req *desc.RetryWebhookDeliveryRequest,
+) (*desc.RetryWebhookDeliveryResponse, error) {
+	h, err := newRetryWebhookDeliveryHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+// handle queues the dead delivery again, it's sent on the next dispatch with all attempts available
+func (h *retryWebhookDeliveryHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		webhookQuery := tx.NewWebhookQuery()
+
+		delivery, err := webhookQuery.GetDelivery(h.ctx, h.deliveryID)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		if delivery.Status != desc.WebhookDeliveryStatus_DEAD.String() {
+			return errors.NewNetworkError(codes.FailedPrecondition, "only dead deliveries can be retried").
+				ToGRPCError()
+		}
+		before := map[string]interface{}{"delivery_id": delivery.ID, "delivery_status": delivery.Status}
+
+		delivery.Status = desc.WebhookDeliveryStatus_QUEUED.String()
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = time.Now()
+		h.retriedDelivery, err = webhookQuery.ChangeDelivery(h.ctx, delivery, "status", "attempts", "next_attempt_at")
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		after := map[string]interface{}{"delivery_id": delivery.ID, "delivery_status": delivery.Status}
+		err = audit.Record(h.ctx, tx, audit.TargetWebhook, []int64{delivery.WebhookID}, before, after)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+func (h *retryWebhookDeliveryHandler) response() *desc.RetryWebhookDeliveryResponse {
+	return &desc.RetryWebhookDeliveryResponse{
+		Delivery: newDescWebhookDelivery(h.retriedDelivery),
+	}
+}
+
+type retryWebhookDeliveryHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	deliveryID int64
+
+	retriedDelivery dao.WebhookDeliveryTable
+}
+
+func newRetryWebhookDeliveryHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.RetryWebhookDeliveryRequest,
+) (*retryWebhookDeliveryHandler, error) {
+	h := &retryWebhookDeliveryHandler{
+		ctx:        ctx,
+		dao:        dao,
+		deliveryID: req.GetWebhookDeliveryId(),
+	}
+	return h, h.validate()
+}
+
+func (h *retryWebhookDeliveryHandler) validate() error {
+	if h.deliveryID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "webhook_delivery_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
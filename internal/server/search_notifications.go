@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/types/nulltypes"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) SearchNotifications(
+	ctx context.Context,
+	req *desc.SearchNotificationsRequest,
+) (*desc.SearchNotificationsResponse, error) {
+	h, err := newSearchNotificationsHandler(ctx, s.dao, s.paginator, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *searchNotificationsHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	notificationQuery := h.dao.NewNotificationQuery()
+	if h.pagedByToken() {
+		keyset, err := pageKeyset(h.paginator, h.req, h.pageToken, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+		notifications, err := notificationQuery.GetNotificationsByFilterAfter(h.ctx, h.filter, keyset, h.limit+1)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.notifications, h.nextPageToken, err = nextPage(h.paginator, h.req, notifications, h.limit, h.tokenOrder)
+		if err != nil {
+			return err
+		}
+	} else {
+		notifications, err := notificationQuery.GetNotificationsByFilter(
+			h.ctx,
+			h.filter,
+			newOrderBy(h.orderBy, notificationOrderFields),
+			h.limit,
+			h.offset,
+		)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.notifications = notifications
+	}
+
+	totalCount, err := notificationQuery.CountNotificationsByFilter(h.ctx, h.filter)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.totalCount = totalCount
+	return nil
+}
+
+func (h *searchNotificationsHandler) response() *desc.SearchNotificationsResponse {
+	notifications := make([]*desc.Notification, 0, len(h.notifications))
+	for idx := range h.notifications {
+		notifications = append(notifications, newDescNotification(h.notifications[idx]))
+	}
+
+	return &desc.SearchNotificationsResponse{
+		Notifications: notifications,
+		Limit:         int64(h.limit),
+		Offset:        int64(h.offset),
+		Count:         int64(len(notifications)),
+		TotalCount:    h.totalCount,
+		NextPageToken: h.nextPageToken,
+	}
+}
+
+type searchNotificationsHandler struct {
+	ctx       context.Context
+	dao       dao.DAO
+	paginator *pagination.Paginator
+	req       *desc.SearchNotificationsRequest
+
+	filter  dao.NotificationFilter
+	limit   uint64
+	orderBy []*desc.OrderBy
+	tokenPaging
+
+	notifications []dao.NotificationTable
+	totalCount    int64
+	nextPageToken string
+}
+
+func newSearchNotificationsHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	paginator *pagination.Paginator,
+	req *desc.SearchNotificationsRequest,
+) (*searchNotificationsHandler, error) {
+	h := &searchNotificationsHandler{
+		ctx:       ctx,
+		dao:       dao,
+		paginator: paginator,
+		req:       req,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *searchNotificationsHandler) adapt(req *desc.SearchNotificationsRequest) *searchNotificationsHandler {
+	h.filter = dao.NotificationFilter{
+		SenderID:   nulltypes.NewNullInt64(req.SenderId),
+		ReceiverID: nulltypes.NewNullInt64(req.ReceiverId),
+		Text:       req.GetText(),
+	}
+	for _, status := range req.GetStatuses() {
+		h.filter.Statuses = append(h.filter.Statuses, status.String())
+	}
+	if req.From != nil {
+		h.filter.From = sql.NullTime{Time: req.GetFrom().AsTime(), Valid: true}
+	}
+	if req.To != nil {
+		h.filter.To = sql.NullTime{Time: req.GetTo().AsTime(), Valid: true}
+	}
+	if req.HasMedia != nil {
+		h.filter.HasMedia = sql.NullBool{Bool: req.GetHasMedia(), Valid: true}
+	}
+	h.limit = h.paginator.PageSize(uint64(max(req.GetLimit(), 0)))
+	h.orderBy = req.GetOrderBy()
+	// notifications are listed from the newest by default
+	h.tokenPaging = newTokenPaging(
+		uint64(req.GetOffset()),
+		req.GetPageToken(),
+		h.orderBy,
+		notificationOrderFields,
+		dao.OrderBy{Column: "id", Desc: true},
+	)
+	return h
+}
+
+func (h *searchNotificationsHandler) validate() error {
+	if h.limit <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "limit must be specified").
+			ToGRPCError()
+	}
+	for _, status := range h.req.GetStatuses() {
+		if _, ok := desc.NotificationStatus_name[int32(status)]; !ok {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("unknown status %d", status)).
+				ToGRPCError()
+		}
+	}
+	if h.filter.From.Valid && h.filter.To.Valid && !h.filter.From.Time.Before(h.filter.To.Time) {
+		return errors.NewNetworkError(codes.InvalidArgument, "from must be before to").
+			ToGRPCError()
+	}
+	if err := validateOrderBy(h.orderBy, notificationOrderFields); err != nil {
+		return err
+	}
+	return h.validatePaging()
+}
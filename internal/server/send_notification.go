@@ -2,12 +2,21 @@ package server
 
 import (
 	"context"
+	"database/sql"
+	stdErrors "errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
+	"slices"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
 	"telegram-notification-api/internal/clients"
 	"telegram-notification-api/internal/dao"
 	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/events"
+	"telegram-notification-api/internal/links"
+	"telegram-notification-api/internal/ratelimit"
 	"telegram-notification-api/internal/types/nulltypes"
+	"telegram-notification-api/internal/webhooks"
 	"time"
 
 	desc "telegram-notification-api/api"
@@ -17,7 +26,7 @@ func (s *server) SendNotification(
 	ctx context.Context,
 	req *desc.SendNotificationRequest,
 ) (*desc.SendNotificationResponse, error) {
-	h, err := newSendNotificationHandler(ctx, s.dao, s.clients, req)
+	h, err := newSendNotificationHandler(ctx, s.dao, s.clients, s.tracker, s.bus, req)
 	if err != nil {
 		return nil, err
 	}
@@ -32,20 +41,57 @@ func (h *sendNotificationHandler) handle() error {
 	if h == nil {
 		return fmt.Errorf("go nil receiver")
 	}
-	notification, err := h.dao.
-		NewNotificationQuery().
-		CreateNotification(
-			h.ctx,
-			h.senderID,
-			h.receiverIDs,
-			h.message,
-			nulltypes.NewNullString(h.mediaContent),
-			h.now,
-		)
-	if err != nil {
+	if err := h.addGroupReceivers(); err != nil {
+		return err
+	}
+	if err := authz.CheckGroupScope(h.ctx, h.dao.NewGroupQuery(), h.groupIDs, h.receiverIDs); err != nil {
+		return err
+	}
+	if err := h.filterDeletedReceivers(); err != nil {
+		return err
+	}
+	if err := h.filterTopicSubscribers(); err != nil {
+		return err
+	}
+	if err := ratelimit.ReserveMessages(h.ctx, int64(len(h.receiverIDs))); err != nil {
+		return err
+	}
+
+	err := withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		notification, err := tx.
+			NewNotificationQuery().
+			CreateNotification(
+				h.ctx,
+				h.senderID,
+				h.receiverIDs,
+				h.message,
+				nulltypes.NewNullString(h.mediaContent),
+				h.now,
+				nulltypes.NewNullInt64(h.topicID),
+			)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+		h.createdNotification = notification
+
+		err = tx.NewDeliveryQuery().CreateDeliveries(h.ctx, notification.ID, h.receiverIDs, h.now)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		if err = h.createTrackedLinks(tx); err != nil {
+			return err
+		}
+
+		err = audit.Record(h.ctx, tx, audit.TargetNotification, []int64{notification.ID}, nil, notification)
 		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+	if err != nil {
+		return err
 	}
-	h.createdNotification = notification
+	h.publishQueued()
+	defer h.publishCompleted()
+
 	if err = h.sendMessageToTelegram(); err != nil {
 		// если мы не смогли отправить сообщение, надо проставить проблему
 		if err := h.dao.
@@ -57,47 +103,284 @@ func (h *sendNotificationHandler) handle() error {
 			); err != nil {
 			return errors.WrapToNetwork(err).ToGRPCError()
 		}
+		if err := h.enqueueNotificationEvent(webhooks.EventNotificationFailed, err); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
 
+	status, eventType := desc.NotificationStatus_SEND, webhooks.EventNotificationSent
+	var sendErr error
+	failed := h.deliveryCounts[desc.DeliveryStatus_FAILED] + h.deliveryCounts[desc.DeliveryStatus_BLOCKED]
+	if failed > 0 {
+		status, eventType = desc.NotificationStatus_PROBLEM, webhooks.EventNotificationFailed
+		sendErr = fmt.Errorf("%d of %d deliveries failed", failed, len(h.receiverIDs))
+	}
 	err = h.dao.
 		NewNotificationQuery().
-		UpdateNotificationStatus(h.ctx, h.createdNotification.ID, desc.NotificationStatus_SEND.String())
+		UpdateNotificationStatus(h.ctx, h.createdNotification.ID, status.String())
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.createdNotification.Status = status.String()
+
+	err = h.enqueueNotificationEvent(eventType, sendErr)
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+// addGroupReceivers adds members of target groups and their subgroups to receivers
+func (h *sendNotificationHandler) addGroupReceivers() error {
+	if len(h.groupIDs) == 0 {
+		return nil
+	}
+
+	groupQuery := h.dao.NewGroupQuery()
+	for _, groupID := range h.groupIDs {
+		if _, err := groupQuery.GetGroup(h.ctx, groupID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+	}
+
+	memberIDs, err := groupQuery.GetMemberIDs(h.ctx, h.groupIDs)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	for _, memberID := range memberIDs {
+		if !slices.Contains(h.receiverIDs, memberID) {
+			h.receiverIDs = append(h.receiverIDs, memberID)
+		}
+	}
+	if len(h.receiverIDs) == 0 {
+		return errors.NewNetworkError(codes.FailedPrecondition, "target groups have no members").
+			ToGRPCError()
+	}
+	return nil
+}
+
+// filterDeletedReceivers drops deleted users from receivers, they get no message and no delivery
+func (h *sendNotificationHandler) filterDeletedReceivers() error {
+	users, err := h.dao.
+		NewUserQuery().
+		GetUsersByIds(h.ctx, h.receiverIDs, false, nil, uint64(len(h.receiverIDs)), 0)
 	if err != nil {
 		return errors.WrapToNetwork(err).ToGRPCError()
 	}
-	h.createdNotification.Status = desc.NotificationStatus_SEND.String()
+	existing := make(map[int64]bool, len(users))
+	for _, user := range users {
+		existing[user.Id] = true
+	}
+
+	receiverIDs := h.receiverIDs[:0]
+	for _, receiverID := range h.receiverIDs {
+		if existing[receiverID] {
+			receiverIDs = append(receiverIDs, receiverID)
+		}
+	}
+	if len(receiverIDs) == 0 {
+		return errors.NewNetworkError(codes.FailedPrecondition, "all receivers are deleted").ToGRPCError()
+	}
+	h.receiverIDs = receiverIDs
 	return nil
 }
 
-func (h *sendNotificationHandler) sendMessageToTelegram() error {
-	var err error
-	var user dao.UserTable
+// filterTopicSubscribers leaves only receivers subscribed to the topic of the notification
+func (h *sendNotificationHandler) filterTopicSubscribers() error {
+	if h.topicID == nil {
+		return nil
+	}
 
+	topicQuery := h.dao.NewTopicQuery()
+	if _, err := topicQuery.GetTopic(h.ctx, *h.topicID); err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+
+	subscribed, err := topicQuery.GetSubscribedUserIDs(h.ctx, *h.topicID, h.receiverIDs)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if len(subscribed) == 0 {
+		return errors.NewNetworkError(codes.FailedPrecondition, "none of receivers is subscribed to the topic").
+			ToGRPCError()
+	}
+	h.receiverIDs = subscribed
+	return nil
+}
+
+// sendMessageToTelegram sends the message to every receiver, a receiver the message failed to reach
+// doesn't stop sending to the rest. An error is returned only if statuses of deliveries can't be saved.
+func (h *sendNotificationHandler) sendMessageToTelegram() error {
 	for idx := range h.receiverIDs {
-		user, err = h.dao.NewUserQuery().GetUser(h.ctx, h.receiverIDs[idx])
+		user, err := h.dao.NewUserQuery().GetUser(h.ctx, h.receiverIDs[idx])
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			// the receiver was deleted after the notification had been created
+			if err = h.skipDeliveries(h.receiverIDs[idx : idx+1]); err != nil {
+				return err
+			}
+			continue
+		}
 		if err != nil {
+			if err := h.skipDeliveries(h.receiverIDs[idx:]); err != nil {
+				return err
+			}
 			return err
 		}
+		message := h.message
+		if len(h.linkIDs) > 0 {
+			message = h.tracker.Rewrite(h.message, h.linkIDs, h.receiverIDs[idx])
+		}
 		err = h.clients.TelegramClient().SendMessage(
 			h.ctx,
 			user.TelegramId,
-			h.message,
+			message,
 			h.mediaContent,
 			!desc.UserNotificationStatus(desc.UserNotificationStatus_value[user.NotificationStatus]).
 				ToBool(),
 		)
 		if err != nil {
+			status := desc.DeliveryStatus_FAILED
+			if stdErrors.Is(err, clients.ErrBotBlocked) {
+				status = desc.DeliveryStatus_BLOCKED
+			}
+			if err = h.updateDeliveryStatus(h.receiverIDs[idx], status, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		ratelimit.MessagesSent(h.ctx, 1)
+		if err = h.updateDeliveryStatus(h.receiverIDs[idx], desc.DeliveryStatus_DELIVERED, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTrackedLinks saves urls of the message as tracked links if the notification tracks links
+func (h *sendNotificationHandler) createTrackedLinks(tx dao.DAO) error {
+	if !h.trackLinks {
+		return nil
+	}
+	urls := h.tracker.URLs(h.message)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	trackedLinks, err := tx.NewLinkQuery().CreateLinks(h.ctx, h.createdNotification.ID, urls)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	h.linkIDs = make(map[string]int64, len(trackedLinks))
+	for _, link := range trackedLinks {
+		h.linkIDs[link.URL] = link.ID
+	}
+	return nil
+}
+
+// skipDeliveries marks deliveries to the receivers that won't be sent as skipped
+func (h *sendNotificationHandler) skipDeliveries(receiverIDs []int64) error {
+	for _, receiverID := range receiverIDs {
+		if err := h.updateDeliveryStatus(receiverID, desc.DeliveryStatus_SKIPPED, ""); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// updateDeliveryStatus changes the status of the delivery to the user, reason is saved only if it's not empty
+func (h *sendNotificationHandler) updateDeliveryStatus(userID int64, status desc.DeliveryStatus, reason string) error {
+	now := time.Now()
+	err := h.dao.NewDeliveryQuery().UpdateDeliveryStatus(
+		h.ctx,
+		h.createdNotification.ID,
+		userID,
+		status.String(),
+		sql.NullString{String: reason, Valid: reason != ""},
+		now,
+	)
+	if err != nil {
+		return err
+	}
+	h.deliveryCounts[status]++
+	if err = h.enqueueDeliveryEvents(userID, status, reason); err != nil {
+		return err
+	}
+	h.bus.Publish(h.ctx, events.Event{
+		NotificationID: h.createdNotification.ID,
+		UserID:         userID,
+		Status:         status.String(),
+		Error:          reason,
+		At:             now,
+	})
+	return nil
+}
+
+// enqueueNotificationEvent sends the notification event to webhooks, sendErr is the reason of a failure
+func (h *sendNotificationHandler) enqueueNotificationEvent(eventType string, sendErr error) error {
+	data := webhooks.NotificationData{
+		NotificationID: h.createdNotification.ID,
+		SenderID:       h.senderID,
+		ReceiverCount:  int64(len(h.receiverIDs)),
+		DeliveredCount: h.deliveryCounts[desc.DeliveryStatus_DELIVERED],
+		FailedCount:    h.deliveryCounts[desc.DeliveryStatus_FAILED],
+		BlockedCount:   h.deliveryCounts[desc.DeliveryStatus_BLOCKED],
+	}
+	if sendErr != nil {
+		data.Error = sendErr.Error()
+	}
+	return webhooks.Enqueue(h.ctx, h.dao, eventType, data)
+}
+
+// enqueueDeliveryEvents sends events of a failed delivery to webhooks
+func (h *sendNotificationHandler) enqueueDeliveryEvents(userID int64, status desc.DeliveryStatus, reason string) error {
+	if status != desc.DeliveryStatus_FAILED && status != desc.DeliveryStatus_BLOCKED {
+		return nil
+	}
+	data := webhooks.DeliveryData{
+		NotificationID: h.createdNotification.ID,
+		UserID:         userID,
+		Status:         status.String(),
+		Error:          reason,
+	}
+	if err := webhooks.Enqueue(h.ctx, h.dao, webhooks.EventDeliveryFailed, data); err != nil {
+		return err
+	}
+	if status == desc.DeliveryStatus_BLOCKED {
+		return webhooks.Enqueue(h.ctx, h.dao, webhooks.EventUserBlockedBot, data)
+	}
+	return nil
+}
+
+// publishQueued tells watchers about pending deliveries of the created notification
+func (h *sendNotificationHandler) publishQueued() {
+	queued := make([]events.Event, 0, len(h.receiverIDs))
+	for _, receiverID := range h.receiverIDs {
+		queued = append(queued, events.Event{
+			NotificationID: h.createdNotification.ID,
+			UserID:         receiverID,
+			Status:         desc.DeliveryStatus_PENDING.String(),
+			At:             h.now,
+		})
+	}
+	h.bus.Publish(h.ctx, queued...)
+}
+
+// publishCompleted tells watchers that sending of the notification has ended
+func (h *sendNotificationHandler) publishCompleted() {
+	h.bus.Publish(h.ctx, events.Event{
+		NotificationID: h.createdNotification.ID,
+		Completed:      true,
+		At:             time.Now(),
+	})
+}
+
 func (h *sendNotificationHandler) response() *desc.SendNotificationResponse {
 	return &desc.SendNotificationResponse{
 		NotificationId: h.createdNotification.ID,
 		MessageStatus:  desc.NotificationStatus(desc.NotificationStatus_value[h.createdNotification.Status]),
+		ReceiverCount:  int64(len(h.receiverIDs)),
+		DeliveredCount: h.deliveryCounts[desc.DeliveryStatus_DELIVERED],
+		FailedCount:    h.deliveryCounts[desc.DeliveryStatus_FAILED],
+		BlockedCount:   h.deliveryCounts[desc.DeliveryStatus_BLOCKED],
+		SkippedCount:   h.deliveryCounts[desc.DeliveryStatus_SKIPPED],
 	}
 }
 
@@ -105,27 +388,42 @@ type sendNotificationHandler struct {
 	ctx     context.Context
 	dao     dao.DAO
 	clients clients.Clients
+	tracker *links.Tracker
+	bus     *events.Bus
 
 	senderID     int64
 	receiverIDs  []int64
 	message      string
 	now          time.Time
 	mediaContent *string
+	topicID      *int64
+	groupIDs     []int64
+	trackLinks   bool
 
 	createdNotification dao.NotificationTable
+	// linkIDs are ids of tracked links by their urls
+	linkIDs map[string]int64
+	// deliveryCounts are numbers of deliveries by the status they got
+	deliveryCounts map[desc.DeliveryStatus]int64
 }
 
 func newSendNotificationHandler(
 	ctx context.Context,
 	dao dao.DAO,
 	clients clients.Clients,
+	tracker *links.Tracker,
+	bus *events.Bus,
 	req *desc.SendNotificationRequest,
 ) (*sendNotificationHandler, error) {
 	h := &sendNotificationHandler{
 		ctx:     ctx,
 		dao:     dao,
 		clients: clients,
+		tracker: tracker,
+		bus:     bus,
 		now:     time.Now(),
+
+		deliveryCounts: make(map[desc.DeliveryStatus]int64),
 	}
 	return h.adapt(req), h.validate()
 }
@@ -135,8 +433,8 @@ func (h *sendNotificationHandler) validate() error {
 		return errors.NewNetworkError(codes.InvalidArgument, "sender_id must be specified").
 			ToGRPCError()
 	}
-	if len(h.receiverIDs) == 0 {
-		return errors.NewNetworkError(codes.InvalidArgument, "receiver_ids must be specified").
+	if len(h.receiverIDs) == 0 && len(h.groupIDs) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "receiver_ids or group_ids must be specified").
 			ToGRPCError()
 	}
 	if h.message == "" {
@@ -147,13 +445,29 @@ func (h *sendNotificationHandler) validate() error {
 		return errors.NewNetworkError(codes.InvalidArgument, "media_content must be specified").
 			ToGRPCError()
 	}
+	if h.topicID != nil && *h.topicID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be positive").
+			ToGRPCError()
+	}
+	if h.trackLinks && !h.tracker.Enabled() {
+		return errors.NewNetworkError(codes.FailedPrecondition, "link tracking is disabled").
+			ToGRPCError()
+	}
 	return nil
 }
 
 func (h *sendNotificationHandler) adapt(req *desc.SendNotificationRequest) *sendNotificationHandler {
 	h.mediaContent = req.MediaContent
 	h.senderID = req.GetSenderId()
-	h.receiverIDs = req.GetReceiverIds()
+	// every receiver gets one message and one delivery, even if it's repeated in the request
+	for _, receiverID := range req.GetReceiverIds() {
+		if !slices.Contains(h.receiverIDs, receiverID) {
+			h.receiverIDs = append(h.receiverIDs, receiverID)
+		}
+	}
 	h.message = req.GetMessage()
+	h.topicID = req.TopicId
+	h.groupIDs = req.GetGroupIds()
+	h.trackLinks = req.GetTrackLinks()
 	return h
 }
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stdErrors "errors"
+	"github.com/jmoiron/sqlx/types"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/events"
+	"testing"
+	"time"
+
+	desc "telegram-notification-api/api"
+)
+
+// sendDAO keeps users, the sent notification and statuses of its deliveries in memory
+type sendDAO struct {
+	dao.DAO
+	users              map[int64]dao.UserTable
+	notificationStatus string
+	deliveries         map[int64]string
+	// events are types of enqueued webhook events with ids of users of delivery events
+	events []string
+}
+
+func newSendDAO(users ...dao.UserTable) *sendDAO {
+	d := &sendDAO{
+		users:      make(map[int64]dao.UserTable),
+		deliveries: make(map[int64]string),
+	}
+	for _, user := range users {
+		d.users[user.Id] = user
+	}
+	return d
+}
+
+func (d *sendDAO) WithTx(_ context.Context, f func(tx dao.DAO) error) error {
+	return f(d)
+}
+
+func (d *sendDAO) NewUserQuery() dao.UserQuery {
+	return sendUserQuery{d: d}
+}
+
+func (d *sendDAO) NewGroupQuery() dao.GroupQuery {
+	return nil
+}
+
+func (d *sendDAO) NewNotificationQuery() dao.NotificationQuery {
+	return sendNotificationQuery{d: d}
+}
+
+func (d *sendDAO) NewDeliveryQuery() dao.DeliveryQuery {
+	return sendDeliveryQuery{d: d}
+}
+
+func (d *sendDAO) NewAuditLogQuery() dao.AuditLogQuery {
+	return importAuditLogQuery{}
+}
+
+func (d *sendDAO) NewWebhookQuery() dao.WebhookQuery {
+	return sendWebhookQuery{d: d}
+}
+
+func (d *sendDAO) NewEventQuery() dao.EventQuery {
+	return sendEventQuery{}
+}
+
+type sendUserQuery struct {
+	dao.UserQuery
+	d *sendDAO
+}
+
+func (q sendUserQuery) GetUser(_ context.Context, userID int64) (dao.UserTable, error) {
+	user, ok := q.d.users[userID]
+	if !ok {
+		return user, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (q sendUserQuery) GetUsersByIds(
+	_ context.Context,
+	userIDs []int64,
+	_ bool,
+	_ []dao.OrderBy,
+	_ uint64,
+	_ uint64,
+) ([]dao.UserTable, error) {
+	var users []dao.UserTable
+	for _, userID := range userIDs {
+		if user, ok := q.d.users[userID]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type sendNotificationQuery struct {
+	dao.NotificationQuery
+	d *sendDAO
+}
+
+func (q sendNotificationQuery) CreateNotification(
+	_ context.Context,
+	senderID int64,
+	receiverIDs []int64,
+	message string,
+	_ sql.NullString,
+	date time.Time,
+	_ sql.NullInt64,
+) (dao.NotificationTable, error) {
+	q.d.notificationStatus = desc.NotificationStatus_CREATED.String()
+	return dao.NotificationTable{
+		ID:          1,
+		SenderID:    senderID,
+		ReceiverIDs: receiverIDs,
+		Message:     message,
+		Status:      q.d.notificationStatus,
+		Date:        date,
+	}, nil
+}
+
+func (q sendNotificationQuery) UpdateNotificationStatus(_ context.Context, _ int64, status string) error {
+	q.d.notificationStatus = status
+	return nil
+}
+
+type sendDeliveryQuery struct {
+	dao.DeliveryQuery
+	d *sendDAO
+}
+
+func (q sendDeliveryQuery) CreateDeliveries(_ context.Context, _ int64, userIDs []int64, _ time.Time) error {
+	for _, userID := range userIDs {
+		q.d.deliveries[userID] = desc.DeliveryStatus_PENDING.String()
+	}
+	return nil
+}
+
+func (q sendDeliveryQuery) UpdateDeliveryStatus(
+	_ context.Context,
+	_ int64,
+	userID int64,
+	status string,
+	_ sql.NullString,
+	_ time.Time,
+) error {
+	q.d.deliveries[userID] = status
+	return nil
+}
+
+type sendWebhookQuery struct {
+	dao.WebhookQuery
+	d *sendDAO
+}
+
+func (q sendWebhookQuery) EnqueueDeliveries(_ context.Context, eventType string, body types.JSONText, _ time.Time) error {
+	var payload struct {
+		Data struct {
+			UserID int64 `json:"user_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	if payload.Data.UserID > 0 {
+		eventType += ":" + strconv.FormatInt(payload.Data.UserID, 10)
+	}
+	q.d.events = append(q.d.events, eventType)
+	return nil
+}
+
+type sendEventQuery struct {
+	dao.EventQuery
+}
+
+func (sendEventQuery) Notify(context.Context, string, []string) error {
+	return nil
+}
+
+// telegramClient fails to send messages to telegram ids of errs
+type telegramClient struct {
+	clients.TelegramClient
+	errs map[int64]error
+}
+
+func (c telegramClient) SendMessage(_ context.Context, receiverID int64, _ string, _ *string, _ bool) error {
+	return c.errs[receiverID]
+}
+
+type sendClients struct {
+	telegram telegramClient
+}
+
+func (c sendClients) TelegramClient() clients.TelegramClient {
+	return c.telegram
+}
+
+func TestSendNotificationFailedReceivers(t *testing.T) {
+	users := []dao.UserTable{
+		{Id: 1, TelegramId: 101, NotificationStatus: desc.UserNotificationStatus_ON.String()},
+		{Id: 2, TelegramId: 102, NotificationStatus: desc.UserNotificationStatus_ON.String()},
+		{Id: 3, TelegramId: 103, NotificationStatus: desc.UserNotificationStatus_ON.String()},
+		{Id: 4, TelegramId: 104, NotificationStatus: desc.UserNotificationStatus_ON.String()},
+	}
+	errBlocked := stdErrors.Join(clients.ErrBotBlocked, stdErrors.New("forbidden"))
+	errTimeout := stdErrors.New("timeout")
+
+	tests := []struct {
+		name           string
+		errs           map[int64]error
+		wantStatus     desc.NotificationStatus
+		wantDeliveries map[int64]string
+		wantCounts     [4]int64
+		wantEvents     []string
+	}{
+		{
+			name:       "all delivered",
+			wantStatus: desc.NotificationStatus_SEND,
+			wantDeliveries: map[int64]string{
+				1: desc.DeliveryStatus_DELIVERED.String(),
+				2: desc.DeliveryStatus_DELIVERED.String(),
+				3: desc.DeliveryStatus_DELIVERED.String(),
+				4: desc.DeliveryStatus_DELIVERED.String(),
+			},
+			wantCounts: [4]int64{4, 0, 0, 0},
+			wantEvents: []string{"notification.sent"},
+		},
+		{
+			name:       "sending goes on past failed receivers",
+			errs:       map[int64]error{102: errBlocked, 103: errTimeout},
+			wantStatus: desc.NotificationStatus_PROBLEM,
+			wantDeliveries: map[int64]string{
+				1: desc.DeliveryStatus_DELIVERED.String(),
+				2: desc.DeliveryStatus_BLOCKED.String(),
+				3: desc.DeliveryStatus_FAILED.String(),
+				4: desc.DeliveryStatus_DELIVERED.String(),
+			},
+			wantCounts: [4]int64{2, 1, 1, 0},
+			wantEvents: []string{
+				"delivery.failed:2",
+				"user.blocked_bot:2",
+				"delivery.failed:3",
+				"notification.failed",
+			},
+		},
+		{
+			name:       "every receiver failed",
+			errs:       map[int64]error{101: errTimeout, 102: errTimeout, 103: errBlocked, 104: errTimeout},
+			wantStatus: desc.NotificationStatus_PROBLEM,
+			wantDeliveries: map[int64]string{
+				1: desc.DeliveryStatus_FAILED.String(),
+				2: desc.DeliveryStatus_FAILED.String(),
+				3: desc.DeliveryStatus_BLOCKED.String(),
+				4: desc.DeliveryStatus_FAILED.String(),
+			},
+			wantCounts: [4]int64{0, 3, 1, 0},
+			wantEvents: []string{
+				"delivery.failed:1",
+				"delivery.failed:2",
+				"delivery.failed:3",
+				"user.blocked_bot:3",
+				"delivery.failed:4",
+				"notification.failed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newSendDAO(users...)
+			bus := events.NewBus(d, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			h, err := newSendNotificationHandler(
+				context.Background(),
+				d,
+				sendClients{telegram: telegramClient{errs: tt.errs}},
+				nil,
+				bus,
+				&desc.SendNotificationRequest{SenderId: 1, ReceiverIds: []int64{1, 2, 3, 4}, Message: "hi"},
+			)
+			if err != nil {
+				t.Fatalf("newSendNotificationHandler() error = %v", err)
+			}
+			if err = h.handle(); err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+
+			resp := h.response()
+			if resp.GetMessageStatus() != tt.wantStatus || d.notificationStatus != tt.wantStatus.String() {
+				t.Errorf("status = %v, stored %v, want %v", resp.GetMessageStatus(), d.notificationStatus, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(d.deliveries, tt.wantDeliveries) {
+				t.Errorf("deliveries = %v, want %v", d.deliveries, tt.wantDeliveries)
+			}
+			counts := [4]int64{
+				resp.GetDeliveredCount(),
+				resp.GetFailedCount(),
+				resp.GetBlockedCount(),
+				resp.GetSkippedCount(),
+			}
+			if counts != tt.wantCounts {
+				t.Errorf("delivered, failed, blocked, skipped = %v, want %v", counts, tt.wantCounts)
+			}
+			if !reflect.DeepEqual(d.events, tt.wantEvents) {
+				t.Errorf("webhook events = %v, want %v", d.events, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -3,22 +3,48 @@ package server
 import (
 	desc "telegram-notification-api/api"
 	"telegram-notification-api/internal/clients"
+	"telegram-notification-api/internal/config"
 	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/events"
+	"telegram-notification-api/internal/links"
+	"telegram-notification-api/internal/pagination"
+	"telegram-notification-api/internal/ratelimit"
 )
 
 type server struct {
 	dao     dao.DAO
 	clients clients.Clients
+	limiter *ratelimit.Limiter
+	// paginator caps page sizes and issues page tokens of lists
+	paginator *pagination.Paginator
+	// tracker rewrites links of notifications sent with track_links
+	tracker *links.Tracker
+	// bus passes delivery events to watchers of notifications
+	bus       *events.Bus
+	importCfg config.Import
 
 	desc.UnimplementedTelegramNotificationServiceServer
 }
 
 func (s *server) mustEmbedUnimplementedTelegramNotificationServiceServer() {}
 
-func NewServer(dao dao.DAO, clients clients.Clients) desc.TelegramNotificationServiceServer {
+func NewServer(
+	dao dao.DAO,
+	clients clients.Clients,
+	limiter *ratelimit.Limiter,
+	paginator *pagination.Paginator,
+	tracker *links.Tracker,
+	bus *events.Bus,
+	importCfg config.Import,
+) desc.TelegramNotificationServiceServer {
 	s := &server{
-		dao:     dao,
-		clients: clients,
+		dao:       dao,
+		clients:   clients,
+		limiter:   limiter,
+		paginator: paginator,
+		tracker:   tracker,
+		bus:       bus,
+		importCfg: importCfg,
 	}
 	return s
 }
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) SubscribeToTopic(
+	ctx context.Context,
+	req *desc.SubscribeToTopicRequest,
+) (*desc.SubscribeToTopicResponse, error) {
+	h, err := newSubscribeToTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *subscribeToTopicHandler) response() *desc.SubscribeToTopicResponse {
+	return &desc.SubscribeToTopicResponse{}
+}
+
+func (h *subscribeToTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if err := authz.CheckSelfOrAdmin(h.ctx, h.userID); err != nil {
+		return err
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		if err := tx.NewTopicQuery().Subscribe(h.ctx, h.userID, h.topicID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		subscriber := map[string]interface{}{"subscriber_id": h.userID}
+		err := audit.Record(h.ctx, tx, audit.TargetTopic, []int64{h.topicID}, nil, subscriber)
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type subscribeToTopicHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID  int64
+	topicID int64
+}
+
+func newSubscribeToTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.SubscribeToTopicRequest,
+) (*subscribeToTopicHandler, error) {
+	h := &subscribeToTopicHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *subscribeToTopicHandler) adapt(req *desc.SubscribeToTopicRequest) *subscribeToTopicHandler {
+	h.userID = req.GetUserId()
+	h.topicID = req.GetTopicId()
+	return h
+}
+
+func (h *subscribeToTopicHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	if h.topicID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
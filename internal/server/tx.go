@@ -0,0 +1,13 @@
+package server
+
+import (
+	"context"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+)
+
+// withTx runs f inside a transaction, errors of f keep their codes
+// and errors of the transaction itself are converted like errors of queries
+func withTx(ctx context.Context, d dao.DAO, f func(tx dao.DAO) error) error {
+	return errors.WrapToNetwork(d.WithTx(ctx, f)).ToGRPCError()
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"telegram-notification-api/internal/audit"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/webhooks"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) UnsubscribeFromTopic(
+	ctx context.Context,
+	req *desc.UnsubscribeFromTopicRequest,
+) (*desc.UnsubscribeFromTopicResponse, error) {
+	h, err := newUnsubscribeFromTopicHandler(ctx, s.dao, req)
+	if err != nil {
+		return nil, err
+	}
+	err = h.handle()
+	if err != nil {
+		return nil, err
+	}
+	return h.response(), nil
+}
+
+func (h *unsubscribeFromTopicHandler) response() *desc.UnsubscribeFromTopicResponse {
+	return &desc.UnsubscribeFromTopicResponse{}
+}
+
+func (h *unsubscribeFromTopicHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+	if err := authz.CheckSelfOrAdmin(h.ctx, h.userID); err != nil {
+		return err
+	}
+
+	return withTx(h.ctx, h.dao, func(tx dao.DAO) error {
+		if err := tx.NewTopicQuery().Unsubscribe(h.ctx, h.userID, h.topicID); err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		subscriber := map[string]interface{}{"subscriber_id": h.userID}
+		err := audit.Record(h.ctx, tx, audit.TargetTopic, []int64{h.topicID}, subscriber, nil)
+		if err != nil {
+			return errors.WrapToNetwork(err).ToGRPCError()
+		}
+
+		err = webhooks.Enqueue(h.ctx, tx, webhooks.EventUserUnsubscribed, webhooks.UnsubscriptionData{
+			UserID:  h.userID,
+			TopicID: h.topicID,
+		})
+		return errors.WrapToNetwork(err).ToGRPCError()
+	})
+}
+
+type unsubscribeFromTopicHandler struct {
+	ctx context.Context
+	dao dao.DAO
+
+	userID  int64
+	topicID int64
+}
+
+func newUnsubscribeFromTopicHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	req *desc.UnsubscribeFromTopicRequest,
+) (*unsubscribeFromTopicHandler, error) {
+	h := &unsubscribeFromTopicHandler{
+		ctx: ctx,
+		dao: dao,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *unsubscribeFromTopicHandler) adapt(req *desc.UnsubscribeFromTopicRequest) *unsubscribeFromTopicHandler {
+	h.userID = req.GetUserId()
+	h.topicID = req.GetTopicId()
+	return h
+}
+
+func (h *unsubscribeFromTopicHandler) validate() error {
+	if h.userID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "user_id must be specified").
+			ToGRPCError()
+	}
+	if h.topicID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "topic_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
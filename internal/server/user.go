@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/webhooks"
+
+	desc "telegram-notification-api/api"
+)
+
+func newDescUser(user dao.UserTable) *desc.User {
+	var patronymic *string
+	if user.Patronymic.Valid {
+		patronymic = &user.Patronymic.String
+	}
+
+	descUser := &desc.User{
+		UserId:                 user.Id,
+		TelegramId:             user.TelegramId,
+		UserRole:               desc.UserRole(desc.UserRole_value[user.Role]),
+		UserNotificationStatus: desc.UserNotificationStatus(desc.UserNotificationStatus_value[user.NotificationStatus]),
+		Group:                  firstGroup(user.Groups),
+		Groups:                 user.Groups,
+		Fio: &desc.FIO{
+			Firstname:  user.Firstname,
+			Surname:    user.Surname,
+			Patronymic: patronymic,
+		},
+		MobilePhone: user.MobilePhone,
+		UserStatus:  desc.UserStatus(desc.UserStatus_value[user.Status]),
+	}
+	if user.DeletedAt.Valid {
+		descUser.DeletedAt = timestamppb.New(user.DeletedAt.Time)
+	}
+	if user.DeletedBy.Valid {
+		descUser.DeletedBy = &user.DeletedBy.Int64
+	}
+	return descUser
+}
+
+// enqueueUserCreated sends user.created event to webhooks, tx must be the transaction creating the user
+func enqueueUserCreated(ctx context.Context, tx dao.DAO, user dao.UserTable) error {
+	return webhooks.Enqueue(ctx, tx, webhooks.EventUserCreated, webhooks.UserData{
+		UserID:     user.Id,
+		TelegramID: user.TelegramId,
+		Role:       user.Role,
+	})
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"telegram-notification-api/internal/authz"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/events"
+
+	desc "telegram-notification-api/api"
+)
+
+func (s *server) WatchNotification(
+	req *desc.WatchNotificationRequest,
+	stream desc.TelegramNotificationService_WatchNotificationServer,
+) error {
+	h, err := newWatchNotificationHandler(stream.Context(), s.dao, s.bus, stream, req)
+	if err != nil {
+		return err
+	}
+	return h.handle()
+}
+
+func (h *watchNotificationHandler) handle() error {
+	if h == nil {
+		return fmt.Errorf("got nil receiver")
+	}
+
+	notificationQuery := h.dao.NewNotificationQuery()
+	notification, err := notificationQuery.GetNotification(h.ctx, h.notificationID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	// deliveries are watched only by the sender of the notification or an ADMIN
+	if err = authz.CheckSelfOrAdmin(h.ctx, notification.SenderID); err != nil {
+		return err
+	}
+
+	// the status is checked after subscribing so the end of sending can't be missed in between
+	subscription := h.bus.Subscribe(h.notificationID)
+	defer subscription.Close()
+
+	notification, err = notificationQuery.GetNotification(h.ctx, h.notificationID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	if notification.Status != desc.NotificationStatus_CREATED.String() {
+		return h.sendSummary()
+	}
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return errors.WrapToNetwork(h.ctx.Err()).ToGRPCError()
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return errors.NewNetworkError(codes.Aborted, "events are lost, watch the notification again").
+					ToGRPCError()
+			}
+			if event.Completed {
+				return h.sendSummary()
+			}
+			if err = h.sendDelivery(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *watchNotificationHandler) sendDelivery(event events.Event) error {
+	delivery := &desc.DeliveryEvent{
+		UserId:         event.UserID,
+		DeliveryStatus: desc.DeliveryStatus(desc.DeliveryStatus_value[event.Status]),
+		At:             timestamppb.New(event.At),
+	}
+	if event.Error != "" {
+		delivery.DeliveryError = &event.Error
+	}
+	err := h.stream.Send(&desc.WatchNotificationResponse{
+		NotificationId: h.notificationID,
+		Event:          &desc.WatchNotificationResponse_Delivery{Delivery: delivery},
+	})
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+// sendSummary sends the final event with the current status and delivery counts of the notification
+func (h *watchNotificationHandler) sendSummary() error {
+	notification, err := h.dao.NewNotificationQuery().GetNotification(h.ctx, h.notificationID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	rows, err := h.dao.NewDeliveryQuery().GetNotificationDeliveryStats(h.ctx, h.notificationID)
+	if err != nil {
+		return errors.WrapToNetwork(err).ToGRPCError()
+	}
+	stats := &desc.DeliveryStats{}
+	if merged := newDeliveryStats(rows, statsKeyID); len(merged) > 0 {
+		stats = merged[0].stats
+	}
+
+	err = h.stream.Send(&desc.WatchNotificationResponse{
+		NotificationId: h.notificationID,
+		Event: &desc.WatchNotificationResponse_Summary{Summary: &desc.NotificationSummary{
+			NotificationStatus: desc.NotificationStatus(desc.NotificationStatus_value[notification.Status]),
+			Stats:              stats,
+		}},
+	})
+	return errors.WrapToNetwork(err).ToGRPCError()
+}
+
+type watchNotificationHandler struct {
+	ctx    context.Context
+	dao    dao.DAO
+	bus    *events.Bus
+	stream desc.TelegramNotificationService_WatchNotificationServer
+
+	notificationID int64
+}
+
+func newWatchNotificationHandler(
+	ctx context.Context,
+	dao dao.DAO,
+	bus *events.Bus,
+	stream desc.TelegramNotificationService_WatchNotificationServer,
+	req *desc.WatchNotificationRequest,
+) (*watchNotificationHandler, error) {
+	h := &watchNotificationHandler{
+		ctx:    ctx,
+		dao:    dao,
+		bus:    bus,
+		stream: stream,
+	}
+	return h.adapt(req), h.validate()
+}
+
+func (h *watchNotificationHandler) adapt(req *desc.WatchNotificationRequest) *watchNotificationHandler {
+	h.notificationID = req.GetNotificationId()
+	return h
+}
+
+func (h *watchNotificationHandler) validate() error {
+	if h.notificationID <= 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "notification_id must be specified").
+			ToGRPCError()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/url"
+	"slices"
+	"telegram-notification-api/internal/dao"
+	"telegram-notification-api/internal/errors"
+	"telegram-notification-api/internal/webhooks"
+
+	desc "telegram-notification-api/api"
+)
+
+func newDescWebhook(webhook dao.WebhookTable) *desc.Webhook {
+	return &desc.Webhook{
+		WebhookId:  webhook.ID,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Active:     webhook.Active,
+		CreatedAt:  timestamppb.New(webhook.CreatedAt),
+	}
+}
+
+func newDescWebhookDelivery(delivery dao.WebhookDeliveryTable) *desc.WebhookDelivery {
+	descDelivery := &desc.WebhookDelivery{
+		WebhookDeliveryId: delivery.ID,
+		WebhookId:         delivery.WebhookID,
+		EventType:         delivery.EventType,
+		Payload:           delivery.Payload.String(),
+		Status:            desc.WebhookDeliveryStatus(desc.WebhookDeliveryStatus_value[delivery.Status]),
+		Attempts:          int64(delivery.Attempts),
+		NextAttemptAt:     timestamppb.New(delivery.NextAttemptAt),
+		CreatedAt:         timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.LastAttemptAt.Valid {
+		descDelivery.LastAttemptAt = timestamppb.New(delivery.LastAttemptAt.Time)
+	}
+	if delivery.LastStatusCode.Valid {
+		descDelivery.LastStatusCode = &delivery.LastStatusCode.Int64
+	}
+	if delivery.LastError.Valid {
+		descDelivery.LastError = &delivery.LastError.String
+	}
+	return descDelivery
+}
+
+// validateWebhookURL checks that webhooks can be called at the url
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewNetworkError(codes.InvalidArgument, "url must be an absolute http or https url").
+			ToGRPCError()
+	}
+	return nil
+}
+
+// distinctEventTypes returns sorted event types without duplicates
+func distinctEventTypes(eventTypes []string) []string {
+	eventTypes = slices.Clone(eventTypes)
+	slices.Sort(eventTypes)
+	return slices.Compact(eventTypes)
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return errors.NewNetworkError(codes.InvalidArgument, "event_types must be specified").
+			ToGRPCError()
+	}
+	for _, eventType := range eventTypes {
+		if !webhooks.IsEventType(eventType) {
+			return errors.NewNetworkError(codes.InvalidArgument, fmt.Sprintf("unknown event type %q", eventType)).
+				ToGRPCError()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+)
+
+// Refresher recomputes the hourly rollup of delivery stats that dashboards read
+type Refresher struct {
+	cfg config.Stats
+	dao dao.DAO
+	log *slog.Logger
+}
+
+func NewRefresher(cfg config.Stats, d dao.DAO, log *slog.Logger) *Refresher {
+	return &Refresher{
+		cfg: cfg,
+		dao: d,
+		log: log,
+	}
+}
+
+// Run refreshes the rollup every RollupRefreshInterval until ctx is done.
+// A failed refresh is logged and the rollup keeps the previous data.
+func (r *Refresher) Run(ctx context.Context) {
+	if r.cfg.RollupRefreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.RollupRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		started := time.Now()
+		if err := r.dao.NewDeliveryQuery().RefreshDeliveryStats(ctx); err != nil {
+			r.log.Error("can't refresh delivery stats", slog.Any("err", err))
+			continue
+		}
+		r.log.Info("delivery stats refreshed", slog.Duration("took", time.Since(started)))
+	}
+}
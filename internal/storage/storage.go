@@ -2,6 +2,10 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
@@ -15,18 +19,39 @@ type Storage interface {
 	GetX(ctx context.Context, dest interface{}, sq squirrel.Sqlizer) error
 	Select(dest interface{}, sq squirrel.Sqlizer) error
 	SelectX(ctx context.Context, dest interface{}, sq squirrel.Sqlizer) error
+	// WithTx runs f inside a transaction. Storage passed to f is bound to the transaction,
+	// which is committed if f returns nil and rolled back if f fails or panics.
+	// The error of f is returned as is, an error of the rollback is only logged.
+	// Calling WithTx on a storage that is already bound to a transaction reuses it.
+	WithTx(ctx context.Context, f func(tx Storage) error) error
 	Close() error
 }
 
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx
+type queryer interface {
+	sqlx.ExtContext
+	sqlx.Ext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
 type storage struct {
-	db *sqlx.DB
+	db  queryer
+	log *slog.Logger
+
+	conn *sqlx.DB
+	tx   *sqlx.Tx
 }
 
-func NewStorage(dataSourceName string) (Storage, error) {
+func NewStorage(dataSourceName string, log *slog.Logger) (Storage, error) {
 	const driverName = "postgres"
 	db, err := sqlx.Open(driverName, dataSourceName)
 	return &storage{
-		db: db,
+		db:   db,
+		log:  log,
+		conn: db,
 	}, err
 }
 
@@ -80,6 +105,40 @@ func (s *storage) Get(dest interface{}, sq squirrel.Sqlizer) error {
 	return s.db.Get(dest, sql, args...)
 }
 
+func (s *storage) WithTx(ctx context.Context, f func(tx Storage) error) error {
+	if s.tx != nil {
+		return f(s)
+	}
+
+	tx, err := s.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	finished := false
+	// the transaction is rolled back if f fails or panics, a panic goes on after the rollback
+	defer func() {
+		if finished {
+			return
+		}
+		// a transaction of a canceled context is already rolled back by the driver
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.log.Error("can't roll back transaction", slog.Any("err", err))
+		}
+	}()
+
+	if err = f(&storage{db: tx, log: s.log, conn: s.conn, tx: tx}); err != nil {
+		return err
+	}
+	finished = true
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
 func (s *storage) Close() error {
-	return s.db.Close()
+	if s.tx != nil {
+		return errors.New("can't close storage bound to transaction")
+	}
+	return s.conn.Close()
 }
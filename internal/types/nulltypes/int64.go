@@ -0,0 +1,14 @@
+package nulltypes
+
+import "database/sql"
+
+func NewNullInt64(i *int64) sql.NullInt64 {
+	n := sql.NullInt64{
+		Int64: 0,
+		Valid: i != nil,
+	}
+	if i != nil {
+		n.Int64 = *i
+	}
+	return n
+}
@@ -0,0 +1,22 @@
+package stream
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WithContext returns the stream with ctx replacing its context,
+// it's used by stream interceptors to pass values to handlers
+func WithContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,213 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+)
+
+const (
+	// maxErrorLength limits errors saved in the delivery log
+	maxErrorLength = 512
+	// maxResponseSize is the part of a response body that is read so the connection can be reused
+	maxResponseSize = 64 << 10
+	// leaseMargin is added to the request timeout to lease a delivery, it covers recording the attempt
+	leaseMargin = 30 * time.Second
+)
+
+// Dispatcher sends queued deliveries to webhooks and retries failed ones with exponential backoff
+type Dispatcher struct {
+	cfg    config.Webhooks
+	dao    dao.DAO
+	log    *slog.Logger
+	client *http.Client
+}
+
+func NewDispatcher(cfg config.Webhooks, d dao.DAO, log *slog.Logger) (*Dispatcher, error) {
+	const op = "webhooks.NewDispatcher"
+
+	// the timeout bounds the lease of deliveries, without it a hung request lets another instance resend them
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("%s: timeout must be positive", op)
+	}
+	return &Dispatcher{
+		cfg: cfg,
+		dao: d,
+		log: log,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+// Run sends due deliveries every DispatchInterval until ctx is done.
+// Several instances can run it at once, a delivery is claimed by one of them at a time.
+func (d *Dispatcher) Run(ctx context.Context) {
+	if d.cfg.DispatchInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.cfg.DispatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := d.dispatch(ctx); err != nil {
+			d.log.Error("can't dispatch webhook deliveries", slog.Any("err", err))
+		}
+	}
+}
+
+// dispatch sends a batch of due deliveries concurrently and records results of the attempts
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	now := time.Now()
+	// deliveries of the batch are sent at once, so the lease has to outlive a single request
+	leaseUntil := now.Add(d.cfg.Timeout + leaseMargin)
+	deliveries, err := d.dao.NewWebhookQuery().ClaimDueDeliveries(ctx, now, leaseUntil, d.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	webhookIDs := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+	}
+	webhooks, err := d.dao.NewWebhookQuery().GetWebhooksByIDs(ctx, webhookIDs)
+	if err != nil {
+		return err
+	}
+	webhookByID := make(map[int64]dao.WebhookTable, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookByID[webhook.ID] = webhook
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		webhook, ok := webhookByID[delivery.WebhookID]
+		if !ok {
+			// the webhook is deleted together with its deliveries
+			continue
+		}
+		wg.Add(1)
+		go func(delivery dao.WebhookDeliveryTable) {
+			defer wg.Done()
+			statusCode, err := d.send(ctx, webhook, delivery)
+			if err := d.record(ctx, delivery, statusCode, err); err != nil {
+				d.log.Error(
+					"can't record webhook delivery attempt",
+					slog.Int64("delivery_id", delivery.ID),
+					slog.Any("err", err),
+				)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+	return nil
+}
+
+// send posts the payload of the delivery to the webhook, statusCode is 0 if no response was received
+func (d *Dispatcher) send(
+	ctx context.Context,
+	webhook dao.WebhookTable,
+	delivery dao.WebhookDeliveryTable,
+) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// record saves the result of the attempt, a failed delivery is retried later or becomes dead after MaxAttempts
+func (d *Dispatcher) record(ctx context.Context, delivery dao.WebhookDeliveryTable, statusCode int, sendErr error) error {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = sql.NullTime{Time: now, Valid: true}
+	delivery.LastStatusCode = sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}
+	delivery.LastError = sql.NullString{}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = desc.WebhookDeliveryStatus_SUCCEEDED.String()
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = desc.WebhookDeliveryStatus_DEAD.String()
+	default:
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+	if sendErr != nil {
+		message := sendErr.Error()
+		if len(message) > maxErrorLength {
+			message = message[:maxErrorLength]
+		}
+		delivery.LastError = sql.NullString{String: message, Valid: true}
+	}
+
+	_, err := d.dao.NewWebhookQuery().ChangeDelivery(
+		ctx,
+		delivery,
+		"status",
+		"attempts",
+		"next_attempt_at",
+		"last_attempt_at",
+		"last_status_code",
+		"last_error",
+	)
+	return err
+}
+
+// backoff is the delay after the failed attempt, it doubles with every attempt up to MaxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+// Sign returns the X-Webhook-Signature of the body sent at timestamp.
+// Receivers compute the same value to check that the request comes from the service and isn't replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,287 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	desc "telegram-notification-api/api"
+	"telegram-notification-api/internal/config"
+	"telegram-notification-api/internal/dao"
+)
+
+// webhookDAO keeps webhooks and their deliveries in memory like the webhook tables
+type webhookDAO struct {
+	dao.DAO
+	mu         sync.Mutex
+	webhooks   map[int64]dao.WebhookTable
+	deliveries map[int64]dao.WebhookDeliveryTable
+}
+
+func (d *webhookDAO) NewWebhookQuery() dao.WebhookQuery {
+	return webhookQuery{d: d}
+}
+
+type webhookQuery struct {
+	dao.WebhookQuery
+	d *webhookDAO
+}
+
+func (q webhookQuery) ClaimDueDeliveries(
+	_ context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit uint64,
+) ([]dao.WebhookDeliveryTable, error) {
+	q.d.mu.Lock()
+	defer q.d.mu.Unlock()
+
+	var due []dao.WebhookDeliveryTable
+	for id, delivery := range q.d.deliveries {
+		if delivery.Status != desc.WebhookDeliveryStatus_QUEUED.String() || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		if uint64(len(due)) == limit {
+			break
+		}
+		delivery.NextAttemptAt = leaseUntil
+		q.d.deliveries[id] = delivery
+		due = append(due, delivery)
+	}
+	return due, nil
+}
+
+func (q webhookQuery) GetWebhooksByIDs(_ context.Context, webhookIDs []int64) ([]dao.WebhookTable, error) {
+	var webhooks []dao.WebhookTable
+	for _, id := range webhookIDs {
+		if webhook, ok := q.d.webhooks[id]; ok {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+// ChangeDelivery saves the whole delivery, the dispatcher changes only the fields of an attempt
+func (q webhookQuery) ChangeDelivery(
+	_ context.Context,
+	delivery dao.WebhookDeliveryTable,
+	_ ...string,
+) (dao.WebhookDeliveryTable, error) {
+	q.d.mu.Lock()
+	defer q.d.mu.Unlock()
+
+	q.d.deliveries[delivery.ID] = delivery
+	return delivery, nil
+}
+
+func TestDispatcherDispatch(t *testing.T) {
+	tests := []struct {
+		name string
+		// statuses are responses of the webhook to consecutive attempts, the last one repeats
+		statuses       []int
+		unreachable    bool
+		maxAttempts    int
+		wantStatus     desc.WebhookDeliveryStatus
+		wantAttempts   int
+		wantStatusCode sql.NullInt64
+		wantError      bool
+	}{
+		{
+			name:           "delivered",
+			statuses:       []int{http.StatusNoContent},
+			maxAttempts:    3,
+			wantStatus:     desc.WebhookDeliveryStatus_SUCCEEDED,
+			wantAttempts:   1,
+			wantStatusCode: sql.NullInt64{Int64: http.StatusNoContent, Valid: true},
+		},
+		{
+			name:           "delivered after retries",
+			statuses:       []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			maxAttempts:    3,
+			wantStatus:     desc.WebhookDeliveryStatus_SUCCEEDED,
+			wantAttempts:   3,
+			wantStatusCode: sql.NullInt64{Int64: http.StatusOK, Valid: true},
+		},
+		{
+			name:           "dead after max attempts",
+			statuses:       []int{http.StatusInternalServerError},
+			maxAttempts:    3,
+			wantStatus:     desc.WebhookDeliveryStatus_DEAD,
+			wantAttempts:   3,
+			wantStatusCode: sql.NullInt64{Int64: http.StatusInternalServerError, Valid: true},
+			wantError:      true,
+		},
+		{
+			name:           "redirect is a failure",
+			statuses:       []int{http.StatusNotModified},
+			maxAttempts:    2,
+			wantStatus:     desc.WebhookDeliveryStatus_DEAD,
+			wantAttempts:   2,
+			wantStatusCode: sql.NullInt64{Int64: http.StatusNotModified, Valid: true},
+			wantError:      true,
+		},
+		{
+			name:         "unreachable",
+			unreachable:  true,
+			maxAttempts:  2,
+			wantStatus:   desc.WebhookDeliveryStatus_DEAD,
+			wantAttempts: 2,
+			wantError:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const secret = "secret"
+			delivery := dao.WebhookDeliveryTable{
+				ID:        3,
+				WebhookID: 1,
+				EventType: EventDeliveryFailed,
+				Payload:   []byte(`{"id":1}`),
+				Status:    desc.WebhookDeliveryStatus_QUEUED.String(),
+			}
+
+			var mu sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp, _ := strconv.ParseInt(r.Header.Get("X-Webhook-Timestamp"), 10, 64)
+				if got := r.Header.Get("X-Webhook-Signature"); got != Sign(secret, timestamp, body) {
+					t.Errorf("X-Webhook-Signature = %s doesn't match the body", got)
+				}
+				if got := r.Header.Get("X-Webhook-Event"); got != delivery.EventType {
+					t.Errorf("X-Webhook-Event = %s, want %s", got, delivery.EventType)
+				}
+				if got := r.Header.Get("X-Webhook-Delivery"); got != "3" {
+					t.Errorf("X-Webhook-Delivery = %s, want 3", got)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				w.WriteHeader(tt.statuses[min(requests, len(tt.statuses)-1)])
+				requests++
+			}))
+			defer server.Close()
+			if tt.unreachable {
+				server.Close()
+			}
+
+			d := &webhookDAO{
+				webhooks:   map[int64]dao.WebhookTable{1: {ID: 1, URL: server.URL, Secret: secret, Active: true}},
+				deliveries: map[int64]dao.WebhookDeliveryTable{delivery.ID: delivery},
+			}
+			// without InitialBackoff retries are due at once, so every dispatch makes the next attempt
+			dispatcher, err := NewDispatcher(
+				config.Webhooks{Timeout: time.Second, BatchSize: 10, MaxAttempts: tt.maxAttempts},
+				d,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// extra dispatches check that finished deliveries are not sent again
+			for i := 0; i < tt.maxAttempts+2; i++ {
+				if err = dispatcher.dispatch(context.Background()); err != nil {
+					t.Fatalf("dispatch() error = %v", err)
+				}
+			}
+
+			got := d.deliveries[delivery.ID]
+			if got.Status != tt.wantStatus.String() || got.Attempts != tt.wantAttempts {
+				t.Errorf(
+					"delivery is %s after %d attempts, want %s after %d",
+					got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts,
+				)
+			}
+			if !tt.unreachable && requests != tt.wantAttempts {
+				t.Errorf("webhook got %d requests, want %d", requests, tt.wantAttempts)
+			}
+			if got.LastStatusCode != tt.wantStatusCode {
+				t.Errorf("last status code = %v, want %v", got.LastStatusCode, tt.wantStatusCode)
+			}
+			if got.LastError.Valid != tt.wantError {
+				t.Errorf("last error = %v, wantError %v", got.LastError, tt.wantError)
+			}
+			if !got.LastAttemptAt.Valid {
+				t.Errorf("last attempt time is not recorded")
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{
+			name:      "body",
+			secret:    "secret",
+			timestamp: 1700000000,
+			body:      `{"id":1}`,
+			want:      "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		},
+		{
+			name:      "another secret",
+			secret:    "other",
+			timestamp: 1700000000,
+			body:      `{"id":1}`,
+			want:      "sha256=e0cb77fc6d5b2877ec062213c262d236b5dd5a833d29fdc5a058c5fbfa287b47",
+		},
+		{
+			name:      "another timestamp",
+			secret:    "secret",
+			timestamp: 1700000001,
+			body:      `{"id":1}`,
+			want:      "sha256=d0c79a345e51a61362e0123dd2fc00ec01a78397760f2babc7a052bbbf46c313",
+		},
+		{
+			name:   "empty body",
+			secret: "secret",
+			want:   "sha256=3445798a051818ef95def46c2eb62b43d377ce6e3c29b4d0aec3da0e59577f79",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	d, err := NewDispatcher(config.Webhooks{
+		Timeout:        time.Second,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+	}, nil, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 5, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"telegram-notification-api/internal/dao"
+)
+
+// Types of events webhooks can subscribe to
+const (
+	EventNotificationSent   = "notification.sent"
+	EventNotificationFailed = "notification.failed"
+	EventDeliveryFailed     = "delivery.failed"
+	EventUserCreated        = "user.created"
+	EventUserBlockedBot     = "user.blocked_bot"
+	EventUserUnsubscribed   = "user.unsubscribed"
+)
+
+var eventTypes = []string{
+	EventNotificationSent,
+	EventNotificationFailed,
+	EventDeliveryFailed,
+	EventUserCreated,
+	EventUserBlockedBot,
+	EventUserUnsubscribed,
+}
+
+// IsEventType reports whether webhooks can subscribe to events of the type
+func IsEventType(eventType string) bool {
+	return slices.Contains(eventTypes, eventType)
+}
+
+// NotificationData is data of notification.sent and notification.failed events,
+// a notification fails if the message fails to reach any of its receivers
+type NotificationData struct {
+	NotificationID int64  `json:"notification_id"`
+	SenderID       int64  `json:"sender_id"`
+	ReceiverCount  int64  `json:"receiver_count"`
+	DeliveredCount int64  `json:"delivered_count"`
+	FailedCount    int64  `json:"failed_count"`
+	BlockedCount   int64  `json:"blocked_count"`
+	Error          string `json:"error,omitempty"`
+}
+
+// DeliveryData is data of delivery.failed and user.blocked_bot events,
+// delivery.failed is sent for every receiver the message fails to reach
+type DeliveryData struct {
+	NotificationID int64  `json:"notification_id"`
+	UserID         int64  `json:"user_id"`
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+}
+
+// UserData is data of user.created events
+type UserData struct {
+	UserID     int64  `json:"user_id"`
+	TelegramID int64  `json:"telegram_id"`
+	Role       string `json:"role"`
+}
+
+// UnsubscriptionData is data of user.unsubscribed events
+type UnsubscriptionData struct {
+	UserID  int64 `json:"user_id"`
+	TopicID int64 `json:"topic_id"`
+}
+
+// payload is the body of requests to webhooks
+type payload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Enqueue queues the event to webhooks subscribed to its type.
+// If d is bound to a transaction, the event is queued only if the transaction commits.
+func Enqueue(ctx context.Context, d dao.DAO, eventType string, data interface{}) error {
+	const op = "webhooks.Enqueue"
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	now := time.Now()
+	body, err := json.Marshal(payload{
+		ID:        hex.EncodeToString(id),
+		Type:      eventType,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = d.NewWebhookQuery().EnqueueDeliveries(ctx, eventType, body, now); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
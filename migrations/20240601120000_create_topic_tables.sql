@@ -0,0 +1,20 @@
+-- +goose Up
+create table if not exists topics
+(
+    id          bigint generated always as identity primary key not null,
+    name        text unique                                     not null,
+    description text
+);
+
+create table if not exists user_topic_subscriptions
+(
+    user_id  bigint references users (id) on delete cascade  not null,
+    topic_id bigint references topics (id) on delete cascade not null,
+    primary key (user_id, topic_id)
+);
+
+create index if not exists user_topic_subscriptions_topic_id_idx
+    on user_topic_subscriptions (topic_id);
+
+alter table notifications
+    add column if not exists topic_id bigint references topics (id) on delete set null;
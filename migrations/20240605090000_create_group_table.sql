@@ -0,0 +1,20 @@
+-- +goose Up
+create table if not exists groups
+(
+    id          bigint generated always as identity primary key not null,
+    name        text unique                                     not null,
+    description text,
+    parent_id   bigint references groups (id),
+    archived    boolean default false                           not null
+);
+
+create index if not exists groups_parent_id_idx on groups (parent_id);
+
+insert into groups (name)
+select distinct user_group
+from users
+on conflict do nothing;
+
+alter table users
+    add constraint users_user_group_fkey
+        foreign key (user_group) references groups (name) on update cascade;
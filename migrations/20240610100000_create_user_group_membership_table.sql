@@ -0,0 +1,19 @@
+-- +goose Up
+create table if not exists user_group_memberships
+(
+    user_id  bigint references users (id) on delete cascade  not null,
+    group_id bigint references groups (id) on delete cascade not null,
+    primary key (user_id, group_id)
+);
+
+create index if not exists user_group_memberships_group_id_idx
+    on user_group_memberships (group_id);
+
+insert into user_group_memberships (user_id, group_id)
+select u.id, g.id
+from users u
+         join groups g on g.name = u.user_group
+on conflict do nothing;
+
+alter table users
+    drop column if exists user_group;
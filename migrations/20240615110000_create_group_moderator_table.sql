@@ -0,0 +1,7 @@
+-- +goose Up
+create table if not exists group_moderators
+(
+    user_id  bigint references users (id) on delete cascade  not null,
+    group_id bigint references groups (id) on delete cascade not null,
+    primary key (user_id, group_id)
+);
@@ -0,0 +1,9 @@
+-- +goose Up
+create table if not exists api_clients
+(
+    id         bigint generated always as identity primary key not null,
+    name       text                                            not null,
+    key_hash   text unique                                     not null,
+    user_id    bigint references users (id) on delete set null,
+    created_at timestamp default now()                         not null
+);
@@ -0,0 +1,8 @@
+-- +goose Up
+create table if not exists client_daily_usage
+(
+    client   text             not null,
+    day      date             not null,
+    messages bigint default 0 not null,
+    primary key (client, day)
+);
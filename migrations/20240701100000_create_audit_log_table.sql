@@ -0,0 +1,17 @@
+-- +goose Up
+create table if not exists audit_log
+(
+    id            bigint generated always as identity primary key not null,
+    actor_id      bigint,
+    api_client_id bigint,
+    method        text                                            not null,
+    target_type   text                                            not null,
+    target_ids    bigint[] default '{}'                           not null,
+    changes       jsonb    default '{}'                           not null,
+    request_id    text                                            not null,
+    created_at    timestamp default now()                         not null
+);
+
+create index if not exists audit_log_actor_id_idx on audit_log (actor_id);
+create index if not exists audit_log_target_ids_idx on audit_log using gin (target_ids);
+create index if not exists audit_log_created_at_idx on audit_log (created_at);
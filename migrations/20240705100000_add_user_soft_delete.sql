@@ -0,0 +1,7 @@
+-- +goose Up
+alter table users
+    add column if not exists deleted_at timestamp,
+    add column if not exists deleted_by bigint references users (id) on delete set null,
+    add column if not exists purged_at  timestamp;
+
+create index if not exists users_deleted_at_idx on users (deleted_at);
@@ -0,0 +1,12 @@
+-- +goose Up
+create extension if not exists pg_trgm;
+
+-- trigram indexes serve prefix, contains and case-insensitive matching of the fields
+create index if not exists users_firstname_trgm_idx on users using gin (firstname gin_trgm_ops);
+create index if not exists users_surname_trgm_idx on users using gin (surname gin_trgm_ops);
+create index if not exists users_patronymic_trgm_idx on users using gin (patronymic gin_trgm_ops);
+create index if not exists users_mobile_phone_trgm_idx on users using gin (mobile_phone gin_trgm_ops);
+
+-- the expression must be the same as userSearchExpression
+create index if not exists users_search_trgm_idx on users using gin
+    ((lower(firstname || ' ' || surname || ' ' || coalesce(patronymic, '') || ' ' || mobile_phone)) gin_trgm_ops);
@@ -0,0 +1,5 @@
+-- +goose Up
+create index if not exists notifications_sender_id_idx on notifications (sender_id);
+create index if not exists notifications_receiver_ids_idx on notifications using gin (receiver_ids);
+create index if not exists notifications_date_idx on notifications (date);
+create index if not exists notifications_message_trgm_idx on notifications using gin (message gin_trgm_ops);
@@ -0,0 +1,29 @@
+-- +goose Up
+create table if not exists deliveries
+(
+    notification_id bigint references notifications (id) on delete cascade not null,
+    user_id         bigint references users (id) on delete cascade         not null,
+    status          text                                                   not null,
+    error           text,
+    created_at      timestamp                                              not null,
+    updated_at      timestamp                                              not null,
+    primary key (notification_id, user_id)
+);
+
+create index if not exists deliveries_user_id_notification_id_idx
+    on deliveries (user_id, notification_id desc);
+
+insert into deliveries (notification_id, user_id, status, created_at, updated_at)
+select n.id,
+       r.user_id,
+       case n.status
+           when 'SEND' then 'DELIVERED'
+           when 'PROBLEM' then 'FAILED'
+           else 'PENDING'
+           end,
+       n.date,
+       n.date
+from notifications n
+         cross join unnest(n.receiver_ids) as r(user_id)
+         join users u on u.id = r.user_id
+on conflict do nothing;
@@ -0,0 +1,29 @@
+-- +goose Up
+create index if not exists deliveries_created_at_idx on deliveries (created_at);
+
+-- deliveries by hour of creation, status and a dimension: sender of the notification or a group of the receiver
+create materialized view if not exists delivery_stats_hourly as
+select 'sender'                                  as dimension,
+       date_trunc('hour', d.created_at)          as hour,
+       n.sender_id                               as key_id,
+       d.status,
+       count(*)                                  as deliveries,
+       coalesce(sum(extract(epoch from d.updated_at - d.created_at))
+                filter (where d.status = 'DELIVERED'), 0)::double precision as latency_sum
+from deliveries d
+         join notifications n on n.id = d.notification_id
+group by 1, 2, 3, 4
+union all
+select 'group'                                   as dimension,
+       date_trunc('hour', d.created_at)          as hour,
+       m.group_id                                as key_id,
+       d.status,
+       count(*)                                  as deliveries,
+       coalesce(sum(extract(epoch from d.updated_at - d.created_at))
+                filter (where d.status = 'DELIVERED'), 0)::double precision as latency_sum
+from deliveries d
+         join user_group_memberships m on m.user_id = d.user_id
+group by 1, 2, 3, 4;
+
+create unique index if not exists delivery_stats_hourly_key_idx
+    on delivery_stats_hourly (dimension, hour, key_id, status);
@@ -0,0 +1,19 @@
+-- +goose Up
+create table if not exists tracked_links
+(
+    id              bigint generated always as identity primary key          not null,
+    notification_id bigint references notifications (id) on delete cascade not null,
+    url             text                                                   not null,
+    unique (notification_id, url)
+);
+
+create table if not exists link_clicks
+(
+    id         bigint generated always as identity primary key         not null,
+    link_id    bigint references tracked_links (id) on delete cascade not null,
+    user_id    bigint references users (id) on delete cascade         not null,
+    clicked_at timestamp                                              not null
+);
+
+create index if not exists link_clicks_link_id_user_id_idx
+    on link_clicks (link_id, user_id);
@@ -0,0 +1,31 @@
+-- +goose Up
+create table if not exists webhooks
+(
+    id          bigint generated always as identity primary key not null,
+    url         text                                            not null,
+    event_types text[]                                          not null,
+    secret      text                                            not null,
+    active      boolean default true                            not null,
+    created_at  timestamp                                       not null
+);
+
+create table if not exists webhook_deliveries
+(
+    id               bigint generated always as identity primary key     not null,
+    webhook_id       bigint references webhooks (id) on delete cascade not null,
+    event_type       text                                               not null,
+    payload          jsonb                                              not null,
+    status           text                                               not null,
+    attempts         integer default 0                                  not null,
+    next_attempt_at  timestamp                                          not null,
+    last_attempt_at  timestamp,
+    last_status_code integer,
+    last_error       text,
+    created_at       timestamp                                          not null
+);
+
+create index if not exists webhook_deliveries_due_idx
+    on webhook_deliveries (next_attempt_at) where status = 'PENDING';
+
+create index if not exists webhook_deliveries_webhook_id_id_idx
+    on webhook_deliveries (webhook_id, id desc);
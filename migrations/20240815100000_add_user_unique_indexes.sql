@@ -0,0 +1,20 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- runs after a release serving GetUserDuplicates, the duplicates it lists have to be deleted or edited first
+-- +goose StatementBegin
+do
+$$
+begin
+    if exists (select 1 from users where deleted_at is null group by telegram_id having count(*) > 1)
+        or exists (select 1 from users where deleted_at is null group by mobile_phone having count(*) > 1) then
+        raise exception 'users share telegram_id or mobile_phone, resolve the duplicates listed by GetUserDuplicates';
+    end if;
+end
+$$;
+-- +goose StatementEnd
+
+-- an index left invalid by a failed concurrent build is built again
+drop index concurrently if exists users_telegram_id_unique_idx;
+create unique index concurrently users_telegram_id_unique_idx on users (telegram_id) where deleted_at is null;
+drop index concurrently if exists users_mobile_phone_unique_idx;
+create unique index concurrently users_mobile_phone_unique_idx on users (mobile_phone) where deleted_at is null;